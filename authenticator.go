@@ -1,6 +1,8 @@
 package sdkgen
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -55,10 +57,12 @@ func (authenticator *ApiKeyAuthenticator) Intercept(req *http.Request) (*http.Re
 
 type OAuth2Authenticator struct {
 	Credentials OAuth2
+	Tracer      TracerInterface
+	Metrics     MetricsInterface
 }
 
 func (authenticator *OAuth2Authenticator) Intercept(req *http.Request) (*http.Request, error) {
-	accessToken, err := authenticator.GetAccessToken(true, 60*10)
+	accessToken, err := authenticator.getAccessToken(req.Context(), true, 60*10)
 	if err == nil {
 		req.Header.Add("Authorization", "Bearer "+accessToken)
 	}
@@ -91,63 +95,81 @@ func (authenticator *OAuth2Authenticator) BuildRedirectUrl(redirectUrl string, s
 }
 
 func (authenticator *OAuth2Authenticator) FetchAccessTokenByCode(code string) (AccessToken, error) {
-	var httpClient = HttpClientFactory(&HttpBasicAuthenticator{
-		Credentials: HttpBasic{
-			UserName: authenticator.Credentials.ClientId,
-			Password: authenticator.Credentials.ClientSecret,
-		},
-	})
+	return authenticator.fetchAccessTokenByCode(context.Background(), code)
+}
 
-	data := url.Values{}
-	data.Set("grant_type", "authorization_code")
-	data.Set("code", code)
+func (authenticator *OAuth2Authenticator) FetchAccessTokenByClientCredentials() (AccessToken, error) {
+	return authenticator.fetchAccessTokenByClientCredentials(context.Background())
+}
 
-	req, err := http.NewRequest("POST", authenticator.Credentials.TokenUrl, strings.NewReader(data.Encode()))
-	if err != nil {
-		return AccessToken{}, errors.New("could create request to obtain access token by code")
-	}
+func (authenticator *OAuth2Authenticator) FetchAccessTokenByRefresh(refreshToken string) (AccessToken, error) {
+	return authenticator.fetchAccessTokenByRefresh(context.Background(), refreshToken)
+}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+func (authenticator *OAuth2Authenticator) GetAccessToken(automaticRefresh bool, expireThreshold int64) (string, error) {
+	return authenticator.getAccessToken(context.Background(), automaticRefresh, expireThreshold)
+}
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return AccessToken{}, errors.New("could not send obtain access token by code")
-	}
+// accessTokenRequest contains the form fields of an OAuth2 token request
+type accessTokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
 
-	return authenticator.ParseTokenResponse(resp)
+func (authenticator *OAuth2Authenticator) fetchAccessTokenByCode(ctx context.Context, code string) (AccessToken, error) {
+	return authenticator.fetchAccessToken(ctx, accessTokenRequest{
+		GrantType: "authorization_code",
+		Code:      code,
+	})
 }
 
-func (authenticator *OAuth2Authenticator) FetchAccessTokenByClientCredentials() (AccessToken, error) {
-	var httpClient = HttpClientFactory(&HttpBasicAuthenticator{
-		Credentials: HttpBasic{
-			UserName: authenticator.Credentials.ClientId,
-			Password: authenticator.Credentials.ClientSecret,
-		},
+func (authenticator *OAuth2Authenticator) fetchAccessTokenByClientCredentials(ctx context.Context) (AccessToken, error) {
+	return authenticator.fetchAccessToken(ctx, accessTokenRequest{
+		GrantType: "client_credentials",
+		Scope:     strings.Join(authenticator.Credentials.Scopes, ","),
 	})
+}
 
-	data := url.Values{}
-	data.Set("grant_type", "client_credentials")
+func (authenticator *OAuth2Authenticator) fetchAccessTokenByRefresh(ctx context.Context, refreshToken string) (AccessToken, error) {
+	return authenticator.fetchAccessToken(ctx, accessTokenRequest{
+		GrantType:    "refresh_token",
+		RefreshToken: refreshToken,
+	})
+}
 
-	if len(authenticator.Credentials.Scopes) > 0 {
-		data.Set("scope", strings.Join(authenticator.Credentials.Scopes, ","))
-	}
+func (authenticator *OAuth2Authenticator) fetchAccessToken(ctx context.Context, data accessTokenRequest) (AccessToken, error) {
+	token, err := authenticator.traceAccessToken(ctx, data)
 
-	req, err := http.NewRequest("POST", authenticator.Credentials.TokenUrl, strings.NewReader(data.Encode()))
-	if err != nil {
-		return AccessToken{}, errors.New("could create request to obtain access token by code")
+	// only tokens which were obtained automatically are counted, the exchange of an authorization code is a user action
+	if err == nil && authenticator.Metrics != nil && data.GrantType != "authorization_code" {
+		authenticator.Metrics.ObserveTokenRefresh(data.GrantType)
 	}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return token, err
+}
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return AccessToken{}, errors.New("could not send obtain access token by code")
+func (authenticator *OAuth2Authenticator) traceAccessToken(ctx context.Context, data accessTokenRequest) (AccessToken, error) {
+	if authenticator.Tracer != nil {
+		var span SpanInterface
+		ctx, span = authenticator.Tracer.Start(ctx, "oauth2 token")
+		defer span.End()
+
+		span.SetAttribute("oauth2.grant_type", data.GrantType)
+
+		token, err := authenticator.requestAccessToken(ctx, data)
+		if err != nil {
+			span.SetError(err)
+		}
+
+		return token, err
 	}
 
-	return authenticator.ParseTokenResponse(resp)
+	return authenticator.requestAccessToken(ctx, data)
 }
 
-func (authenticator *OAuth2Authenticator) FetchAccessTokenByRefresh(refreshToken string) (AccessToken, error) {
+func (authenticator *OAuth2Authenticator) requestAccessToken(ctx context.Context, data accessTokenRequest) (AccessToken, error) {
 	var httpClient = HttpClientFactory(&HttpBasicAuthenticator{
 		Credentials: HttpBasic{
 			UserName: authenticator.Credentials.ClientId,
@@ -155,16 +177,18 @@ func (authenticator *OAuth2Authenticator) FetchAccessTokenByRefresh(refreshToken
 		},
 	})
 
-	data := url.Values{}
-	data.Set("grant_type", "refresh_token")
-	data.Set("refresh_token", refreshToken)
+	var codec = NewFormCodec()
+	body, err := codec.Encode(data)
+	if err != nil {
+		return AccessToken{}, errors.New("could not encode request to obtain access token")
+	}
 
-	req, err := http.NewRequest("POST", authenticator.Credentials.TokenUrl, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", authenticator.Credentials.TokenUrl, bytes.NewReader(body))
 	if err != nil {
 		return AccessToken{}, errors.New("could create request to obtain access token by code")
 	}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Content-Type", codec.ContentType())
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -174,12 +198,12 @@ func (authenticator *OAuth2Authenticator) FetchAccessTokenByRefresh(refreshToken
 	return authenticator.ParseTokenResponse(resp)
 }
 
-func (authenticator *OAuth2Authenticator) GetAccessToken(automaticRefresh bool, expireThreshold int64) (string, error) {
+func (authenticator *OAuth2Authenticator) getAccessToken(ctx context.Context, automaticRefresh bool, expireThreshold int64) (string, error) {
 	timestamp := time.Now().Unix()
 
 	accessToken, err := authenticator.Credentials.TokenStore.Get()
 	if err == nil || accessToken.GetExpiresInTimestamp() < timestamp {
-		accessToken, err = authenticator.FetchAccessTokenByClientCredentials()
+		accessToken, err = authenticator.fetchAccessTokenByClientCredentials(ctx)
 	}
 
 	if err != nil {
@@ -191,7 +215,7 @@ func (authenticator *OAuth2Authenticator) GetAccessToken(automaticRefresh bool,
 	}
 
 	if automaticRefresh && accessToken.RefreshToken != "" {
-		accessToken, err = authenticator.FetchAccessTokenByRefresh(accessToken.RefreshToken)
+		accessToken, err = authenticator.fetchAccessTokenByRefresh(ctx, accessToken.RefreshToken)
 		if err != nil {
 			return "", errors.New("could not refresh access token")
 		}
@@ -1,9 +1,23 @@
 package sdkgen
 
 import (
+	"context"
 	"net/http"
 )
 
+type ClientOptions struct {
+	Version         string
+	Transport       http.RoundTripper
+	Compression     *Compression
+	Logging         *Logging
+	Tracer          TracerInterface
+	Metrics         MetricsInterface
+	Progress        ProgressFunc
+	MaxResponseSize int64
+	Codec           CodecInterface
+	Codecs          []CodecInterface
+}
+
 type ClientAbstract struct {
 	Authenticator AuthenticatorInterface
 	HttpClient    *http.Client
@@ -39,3 +53,63 @@ func NewClientWithVersion(baseUrl string, credentials CredentialsInterface, vers
 		},
 	}, nil
 }
+
+func NewClientWithOptions(baseUrl string, credentials CredentialsInterface, options ClientOptions) (*ClientAbstract, error) {
+	authenticator, err := AuthenticatorFactory(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	// the authenticator was created for this client so it is safe to instrument it, an authenticator which is passed to
+	// the HttpClientFactoryWithOptions might be shared and is therefore not modified
+	oauth2, ok := authenticator.(*OAuth2Authenticator)
+	if ok {
+		if options.Tracer != nil {
+			oauth2.Tracer = options.Tracer
+		}
+
+		if options.Metrics != nil {
+			oauth2.Metrics = options.Metrics
+		}
+	}
+
+	var parser = &Parser{
+		BaseUrl: baseUrl,
+		Codec:   options.Codec,
+	}
+
+	for _, additional := range options.Codecs {
+		parser.RegisterCodec(additional)
+	}
+
+	return &ClientAbstract{
+		Authenticator: authenticator,
+		HttpClient:    HttpClientFactoryWithOptions(authenticator, options),
+		Parser:        parser,
+	}, nil
+}
+
+// GetCodec returns the codec which is used by the generated methods, the codec is stored at the parser
+func (client *ClientAbstract) GetCodec() CodecInterface {
+	return client.Parser.GetCodec()
+}
+
+// SetCodec replaces the default codec i.e. to enable the strict mode or to use a faster JSON library
+func (client *ClientAbstract) SetCodec(codec CodecInterface) {
+	client.Parser.Codec = codec
+}
+
+// RegisterCodec registers an additional codec which is used for the content type of the codec
+func (client *ClientAbstract) RegisterCodec(codec CodecInterface) {
+	client.Parser.RegisterCodec(codec)
+}
+
+// DialWebSocket opens a WebSocket connection to the provided path using the authenticator of the client
+func (client *ClientAbstract) DialWebSocket(ctx context.Context, path string, parameters map[string]interface{}) (*WebSocket, error) {
+	rawUrl, err := client.Parser.UrlWithError(path, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebSocketDialer(client.Authenticator).Dial(ctx, rawUrl)
+}
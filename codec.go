@@ -0,0 +1,271 @@
+package sdkgen
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CodecInterface encodes request payloads and decodes response bodies, a different implementation can be configured
+// through the ClientOptions i.e. to use a faster JSON library
+type CodecInterface interface {
+	ContentType() string
+	Encode(value interface{}) ([]byte, error)
+	Decode(body io.Reader, target interface{}) error
+}
+
+type StrictMode int
+
+const (
+	StrictModeOff StrictMode = iota
+	StrictModeWarn
+	StrictModeError
+)
+
+const (
+	MismatchUnknownField = "unknown"
+	MismatchMissingField = "missing"
+)
+
+// SchemaMismatch describes a field of a response which does not match the model, the path contains the JSON path of
+// the field i.e. arrayObject[1].name
+type SchemaMismatch struct {
+	Path string
+	Kind string
+}
+
+func (mismatch SchemaMismatch) String() string {
+	return mismatch.Kind + " field " + mismatch.Path
+}
+
+// SchemaMismatchError is returned in strict mode in case the response contains unknown fields or misses fields of the
+// model, the target contains nevertheless the decoded value
+type SchemaMismatchError struct {
+	Mismatches []SchemaMismatch
+}
+
+func (e *SchemaMismatchError) Error() string {
+	var messages = make([]string, 0, len(e.Mismatches))
+	for _, mismatch := range e.Mismatches {
+		messages = append(messages, mismatch.String())
+	}
+
+	return "the response does not match the model: " + strings.Join(messages, ", ")
+}
+
+// JsonCodec is the default codec based on encoding/json. In strict mode the response is compared with the model,
+// every field which is not known by the model and every field of the model without omitempty option which is not
+// contained in the response is reported, with StrictModeWarn through the OnMismatch function and with StrictModeError
+// as SchemaMismatchError. With UseNumber numbers which are decoded into an interface are represented as json.Number so
+// that i.e. int64 ids above 2^53 do not lose precision
+type JsonCodec struct {
+	Strict     StrictMode
+	UseNumber  bool
+	OnMismatch func(mismatches []SchemaMismatch)
+}
+
+func (codec *JsonCodec) ContentType() string {
+	return "application/json"
+}
+
+func (codec *JsonCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (codec *JsonCodec) Decode(body io.Reader, target interface{}) error {
+	if codec.Strict == StrictModeOff {
+		return codec.decode(body, target)
+	}
+
+	// the decoder reads only the top-level value from the body and checks that no further token follows, the raw value
+	// is needed to compare the response with the model
+	var raw json.RawMessage
+	err := codec.decode(body, &raw)
+	if err != nil {
+		return err
+	}
+
+	err = codec.decode(bytes.NewReader(raw), target)
+	if err != nil {
+		return err
+	}
+
+	var document interface{}
+	err = json.Unmarshal(raw, &document)
+	if err != nil {
+		return err
+	}
+
+	var mismatches []SchemaMismatch
+	compareSchema(&mismatches, "", document, reflect.TypeOf(target))
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	if codec.Strict == StrictModeError {
+		return &SchemaMismatchError{Mismatches: mismatches}
+	}
+
+	if codec.OnMismatch != nil {
+		codec.OnMismatch(mismatches)
+	}
+
+	return nil
+}
+
+func (codec *JsonCodec) decode(body io.Reader, target interface{}) error {
+	var decoder = json.NewDecoder(body)
+	if codec.UseNumber {
+		decoder.UseNumber()
+	}
+
+	err := decoder.Decode(target)
+	if err != nil {
+		return err
+	}
+
+	_, err = decoder.Token()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return errors.New("invalid character after top-level value")
+}
+
+func NewJsonCodec() *JsonCodec {
+	return &JsonCodec{}
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// compareSchema compares the decoded document with the type of the model, types which decode themselves are skipped
+// since their structure is not known
+func compareSchema(mismatches *[]SchemaMismatch, path string, document interface{}, modelType reflect.Type) {
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+
+	if reflect.PtrTo(modelType).Implements(jsonUnmarshalerType) || reflect.PtrTo(modelType).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch modelType.Kind() {
+	case reflect.Struct:
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		var fields = getSchemaFields(modelType)
+		for _, name := range sortedJsonKeys(object) {
+			var value = object[name]
+			field, ok := findSchemaField(fields, name)
+			if !ok {
+				*mismatches = append(*mismatches, SchemaMismatch{Path: joinValidationPath(path, name), Kind: MismatchUnknownField})
+				continue
+			}
+
+			compareSchema(mismatches, joinValidationPath(path, field.name), value, modelType.FieldByIndex(field.index).Type)
+		}
+
+		for _, field := range fields {
+			if field.optional {
+				continue
+			}
+
+			if !hasSchemaKey(object, field.name) {
+				*mismatches = append(*mismatches, SchemaMismatch{Path: joinValidationPath(path, field.name), Kind: MismatchMissingField})
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := document.([]interface{})
+		if !ok {
+			return
+		}
+
+		for i, item := range items {
+			compareSchema(mismatches, path+"["+strconv.Itoa(i)+"]", item, modelType.Elem())
+		}
+	case reflect.Map:
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		for _, name := range sortedJsonKeys(object) {
+			compareSchema(mismatches, path+"["+name+"]", object[name], modelType.Elem())
+		}
+	}
+}
+
+type schemaField struct {
+	index    []int
+	name     string
+	optional bool
+}
+
+func getSchemaFields(structType reflect.Type) []schemaField {
+	var fields []schemaField
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || (field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "") {
+			continue
+		}
+
+		var name = field.Name
+		var optional = false
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, options, _ := strings.Cut(tag, ",")
+			if tagName == "-" && options == "" {
+				continue
+			} else if tagName != "" {
+				name = tagName
+			}
+
+			optional = strings.Contains(","+options+",", ",omitempty,")
+		}
+
+		fields = append(fields, schemaField{index: field.Index, name: name, optional: optional})
+	}
+
+	return fields
+}
+
+// findSchemaField returns the field for a key of the document, like encoding/json an exact match is preferred over a
+// case-insensitive match
+func findSchemaField(fields []schemaField, name string) (schemaField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+
+	return schemaField{}, false
+}
+
+func hasSchemaKey(object map[string]interface{}, name string) bool {
+	if _, ok := object[name]; ok {
+		return true
+	}
+
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
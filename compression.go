@@ -0,0 +1,195 @@
+package sdkgen
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CompressorInterface describes a content-coding which can be used to compress request bodies and to decompress
+// response bodies, additional codings like brotli can be plugged in by implementing this interface
+type CompressorInterface interface {
+	GetEncoding() string
+	Compress(writer io.Writer) (io.WriteCloser, error)
+	Decompress(reader io.Reader) (io.ReadCloser, error)
+}
+
+type GzipCompressor struct {
+	Level int
+}
+
+func (compressor *GzipCompressor) GetEncoding() string {
+	return "gzip"
+}
+
+func (compressor *GzipCompressor) Compress(writer io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(writer, compressionLevel(compressor.Level))
+}
+
+func (compressor *GzipCompressor) Decompress(reader io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(reader)
+}
+
+type DeflateCompressor struct {
+	Level int
+}
+
+func (compressor *DeflateCompressor) GetEncoding() string {
+	return "deflate"
+}
+
+func (compressor *DeflateCompressor) Compress(writer io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriterLevel(writer, compressionLevel(compressor.Level))
+}
+
+func (compressor *DeflateCompressor) Decompress(reader io.Reader) (io.ReadCloser, error) {
+	// the deflate coding should be zlib wrapped but some servers send a raw deflate stream, so we check the header
+	buffered := bufio.NewReader(reader)
+	header, err := buffered.Peek(2)
+	if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
+
+// Compression configures the transparent compression of request bodies and the negotiated decompression of
+// response bodies. The request body is only compressed if its size is unknown or at least Threshold bytes
+type Compression struct {
+	Request   CompressorInterface
+	Threshold int64
+	Response  []CompressorInterface
+}
+
+func (compression *Compression) CompressRequest(req *http.Request) (*http.Request, error) {
+	if compression.Request == nil || req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return req, nil
+	}
+
+	if req.ContentLength >= 0 && req.ContentLength < compression.Threshold {
+		return req, nil
+	}
+
+	var compressor = compression.Request
+	var getBody = req.GetBody
+
+	req = req.Clone(req.Context())
+	req.Body = compressBody(compressor, req.Body)
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Encoding", compressor.GetEncoding())
+
+	if getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+
+			return compressBody(compressor, body), nil
+		}
+	}
+
+	return req, nil
+}
+
+func (compression *Compression) GetAcceptEncoding() string {
+	var encodings []string
+	for _, compressor := range compression.Response {
+		encodings = append(encodings, compressor.GetEncoding())
+	}
+
+	return strings.Join(encodings, ", ")
+}
+
+func (compression *Compression) DecompressResponse(resp *http.Response) (*http.Response, error) {
+	var encoding = strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified || (resp.Request != nil && resp.Request.Method == "HEAD") {
+		return resp, nil
+	}
+
+	for _, compressor := range compression.Response {
+		if compressor.GetEncoding() != encoding {
+			continue
+		}
+
+		reader, err := compressor.Decompress(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		resp.Body = &decompressedBody{reader: reader, body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
+func NewCompression() *Compression {
+	return &Compression{
+		Request:   &GzipCompressor{},
+		Threshold: 1024,
+		Response:  []CompressorInterface{&GzipCompressor{}, &DeflateCompressor{}},
+	}
+}
+
+func compressBody(compressor CompressorInterface, body io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		defer body.Close()
+
+		encoder, err := compressor.Compress(writer)
+		if err != nil {
+			writer.CloseWithError(err)
+			return
+		}
+
+		_, err = io.Copy(encoder, body)
+		if err != nil {
+			encoder.Close()
+			writer.CloseWithError(err)
+			return
+		}
+
+		writer.CloseWithError(encoder.Close())
+	}()
+
+	return reader
+}
+
+func compressionLevel(level int) int {
+	if level == 0 {
+		return flate.DefaultCompression
+	}
+
+	return level
+}
+
+type decompressedBody struct {
+	reader io.ReadCloser
+	body   io.ReadCloser
+}
+
+func (body *decompressedBody) Read(p []byte) (int, error) {
+	return body.reader.Read(p)
+}
+
+func (body *decompressedBody) Close() error {
+	body.reader.Close()
+	return body.body.Close()
+}
@@ -0,0 +1,186 @@
+package sdkgen
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+// Date wraps a time which is encoded as RFC 3339 full-date i.e. 2023-02-21
+type Date struct {
+	time.Time
+}
+
+func (date Date) MarshalText() ([]byte, error) {
+	return []byte(date.Format(dateLayout)), nil
+}
+
+func (date *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*date = parsed
+
+	return nil
+}
+
+func (date Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(date.String())
+}
+
+func (date *Date) UnmarshalJSON(data []byte) error {
+	return unmarshalJsonText(data, date.UnmarshalText)
+}
+
+func (date Date) String() string {
+	return date.Format(dateLayout)
+}
+
+// ToTime returns the start of the date in the provided location
+func (date Date) ToTime(location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date of the provided time in the location of the time
+func DateOf(t time.Time) Date {
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return Date{}, errors.New("could not parse date, expected a RFC 3339 full-date: " + value)
+	}
+
+	return Date{Time: parsed}, nil
+}
+
+// Time wraps a time which is encoded as RFC 3339 partial-time i.e. 19:19:00, fractional seconds are only encoded if
+// they are present
+type Time struct {
+	time.Time
+}
+
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.Format(timeLayout)), nil
+}
+
+func (t *Time) UnmarshalText(text []byte) error {
+	parsed, err := ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return unmarshalJsonText(data, t.UnmarshalText)
+}
+
+func (t Time) String() string {
+	return t.Format(timeLayout)
+}
+
+// ToTime returns the time of day at the provided date in the provided location
+func (t Time) ToTime(date Date, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+}
+
+func NewTime(hour int, minute int, second int, nanosecond int) Time {
+	return Time{Time: time.Date(0, 1, 1, hour, minute, second, nanosecond, time.UTC)}
+}
+
+// TimeOf returns the time of day of the provided time in the location of the time
+func TimeOf(t time.Time) Time {
+	return NewTime(t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+}
+
+func ParseTime(value string) (Time, error) {
+	parsed, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return Time{}, errors.New("could not parse time, expected a RFC 3339 partial-time: " + value)
+	}
+
+	return Time{Time: parsed}, nil
+}
+
+// DateTime wraps a time which is encoded as RFC 3339 date-time i.e. 2023-02-21T19:19:00+01:00, the offset of the
+// parsed value is preserved
+type DateTime struct {
+	time.Time
+}
+
+func (dateTime DateTime) MarshalText() ([]byte, error) {
+	return []byte(dateTime.Format(time.RFC3339Nano)), nil
+}
+
+func (dateTime *DateTime) UnmarshalText(text []byte) error {
+	parsed, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+
+	*dateTime = parsed
+
+	return nil
+}
+
+func (dateTime DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dateTime.String())
+}
+
+func (dateTime *DateTime) UnmarshalJSON(data []byte) error {
+	return unmarshalJsonText(data, dateTime.UnmarshalText)
+}
+
+func (dateTime DateTime) String() string {
+	return dateTime.Format(time.RFC3339Nano)
+}
+
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{Time: t}
+}
+
+// ParseDateTime parses a RFC 3339 date-time, the value must contain an offset i.e. Z or +01:00
+func ParseDateTime(value string) (DateTime, error) {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return DateTime{Time: parsed}, nil
+	}
+
+	return DateTime{}, errors.New("could not parse date-time, expected a RFC 3339 date-time: " + value)
+}
+
+// unmarshalJsonText decodes a JSON string and passes the value to the provided unmarshal function, like at the
+// standard library a JSON null leaves the value unchanged
+func unmarshalJsonText(data []byte, unmarshal func(text []byte) error) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+
+	return unmarshal([]byte(text))
+}
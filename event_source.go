@@ -0,0 +1,308 @@
+package sdkgen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a single server-sent event. In case the server has only sent a comment i.e. as keep-alive, the event
+// contains only the comment
+type Event struct {
+	Id      string
+	Event   string
+	Data    string
+	Retry   time.Duration
+	Comment string
+}
+
+func (event Event) IsComment() bool {
+	return event.Data == "" && event.Comment != ""
+}
+
+func (event Event) Decode(target interface{}) error {
+	return json.Unmarshal([]byte(event.Data), target)
+}
+
+func DecodeEvent[T any](event Event) (T, error) {
+	var data T
+	err := event.Decode(&data)
+
+	return data, err
+}
+
+// EventReader parses a text/event-stream according to the HTML living standard
+type EventReader struct {
+	scanner     *bufio.Scanner
+	lastEventId string
+	retry       time.Duration
+}
+
+func (reader *EventReader) Next() (Event, error) {
+	var event Event
+	var data strings.Builder
+	var hasData = false
+	var comments []string
+
+	for reader.scanner.Scan() {
+		var line = reader.scanner.Text()
+		if line == "" {
+			if hasData {
+				event.Id = reader.lastEventId
+				event.Data = strings.TrimSuffix(data.String(), "\n")
+				event.Comment = strings.Join(comments, "\n")
+				return event, nil
+			} else if len(comments) > 0 {
+				return Event{Id: reader.lastEventId, Retry: event.Retry, Comment: strings.Join(comments, "\n")}, nil
+			}
+
+			event = Event{}
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			comments = append(comments, strings.TrimPrefix(line[1:], " "))
+			continue
+		}
+
+		var field = line
+		var value = ""
+		if pos := strings.Index(line, ":"); pos != -1 {
+			field = line[:pos]
+			value = strings.TrimPrefix(line[pos+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteString("\n")
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				reader.lastEventId = value
+			}
+		case "retry":
+			milliseconds, err := strconv.ParseUint(value, 10, 63)
+			if err == nil {
+				// the reconnection time is applied as soon as the field is parsed, also for a block without data
+				event.Retry = time.Duration(milliseconds) * time.Millisecond
+				reader.retry = event.Retry
+			}
+		}
+	}
+
+	err := reader.scanner.Err()
+	if err != nil {
+		return Event{}, err
+	}
+
+	// an incomplete event at the end of the stream is discarded
+	return Event{}, io.EOF
+}
+
+func (reader *EventReader) GetLastEventId() string {
+	return reader.lastEventId
+}
+
+// GetRetry returns the last reconnection time which was sent by the server or 0 in case it was not sent
+func (reader *EventReader) GetRetry() time.Duration {
+	return reader.retry
+}
+
+func NewEventReader(reader io.Reader) *EventReader {
+	var scanner = bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 4096), 1024*1024)
+	scanner.Split(scanEventLines)
+
+	return &EventReader{
+		scanner: scanner,
+	}
+}
+
+// EventSource connects to a text/event-stream endpoint through the authenticated http client and reconnects
+// automatically in case the connection is closed, on reconnect the Last-Event-ID header is sent
+type EventSource struct {
+	HttpClient    *http.Client
+	Url           string
+	Header        http.Header
+	LastEventId   string
+	RetryInterval time.Duration
+	MaxRetries    int
+}
+
+// Subscribe calls the handler for every received event until the context is canceled, the handler returns an error
+// or the server responds with a status code other than 200
+func (source *EventSource) Subscribe(ctx context.Context, handler func(event Event) error) error {
+	var retries = 0
+	for {
+		received, err := source.connect(WithAttempt(ctx, retries+1), handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var handlerError *eventHandlerError
+		if errors.As(err, &handlerError) {
+			return handlerError.err
+		}
+
+		var statusError *eventStatusError
+		if errors.As(err, &statusError) {
+			if statusError.statusCode == http.StatusNoContent {
+				return nil
+			}
+
+			return err
+		}
+
+		if received {
+			retries = 0
+		}
+
+		retries++
+		if source.MaxRetries > 0 && retries > source.MaxRetries {
+			if err == nil {
+				err = io.EOF
+			}
+
+			return err
+		}
+
+		var timer = time.NewTimer(source.getRetryInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (source *EventSource) connect(ctx context.Context, handler func(event Event) error) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", source.Url, nil)
+	if err != nil {
+		return false, &eventStatusError{err: err}
+	}
+
+	for name, values := range source.Header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if source.LastEventId != "" {
+		req.Header.Set("Last-Event-ID", source.LastEventId)
+	}
+
+	resp, err := source.HttpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &eventStatusError{statusCode: resp.StatusCode, err: errors.New("the server returned an unexpected status code: " + strconv.Itoa(resp.StatusCode))}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		return false, &eventStatusError{statusCode: resp.StatusCode, err: errors.New("the server returned an unexpected content type: " + mediaType)}
+	}
+
+	var received = false
+	var reader = NewEventReader(resp.Body)
+	for {
+		event, err := reader.Next()
+		if retry := reader.GetRetry(); retry > 0 {
+			source.RetryInterval = retry
+		}
+
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+		source.LastEventId = reader.GetLastEventId()
+
+		err = handler(event)
+		if err != nil {
+			return received, &eventHandlerError{err: err}
+		}
+	}
+}
+
+func (source *EventSource) getRetryInterval() time.Duration {
+	if source.RetryInterval > 0 {
+		return source.RetryInterval
+	}
+
+	return 3 * time.Second
+}
+
+func NewEventSource(httpClient *http.Client, url string) *EventSource {
+	return &EventSource{
+		HttpClient: httpClient,
+		Url:        url,
+	}
+}
+
+type eventHandlerError struct {
+	err error
+}
+
+func (e *eventHandlerError) Error() string {
+	return e.err.Error()
+}
+
+type eventStatusError struct {
+	statusCode int
+	err        error
+}
+
+func (e *eventStatusError) Error() string {
+	return e.err.Error()
+}
+
+// scanEventLines splits the stream into lines, an event stream can use CRLF, LF or CR as line ending
+func scanEventLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if pos := bytes.IndexAny(data, "\r\n"); pos >= 0 {
+		if data[pos] == '\n' {
+			return pos + 1, data[:pos], nil
+		}
+
+		if pos+1 < len(data) {
+			if data[pos+1] == '\n' {
+				return pos + 2, data[:pos], nil
+			}
+
+			return pos + 1, data[:pos], nil
+		}
+
+		if atEOF {
+			return pos + 1, data[:pos], nil
+		}
+
+		// we need more data to decide whether the CR is followed by a LF
+		return 0, nil, nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
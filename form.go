@@ -0,0 +1,403 @@
+package sdkgen
+
+import (
+	"encoding"
+	"errors"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const FormContentType = "application/x-www-form-urlencoded"
+
+const (
+	FormNotationBracket = "bracket"
+	FormNotationDot     = "dot"
+)
+
+// FormCodec encodes a model as application/x-www-form-urlencoded body. Like at the query parameters the model is
+// converted through its JSON representation and every value is encoded through EncodeValue. Nested objects use the
+// configured notation i.e. object[name]=foo for the bracket or object.name=foo for the dot notation, objects inside an
+// array contain the index i.e. items[0][name]=foo and arrays of scalar values are serialized according to the
+// ArrayStyle which uses by default the form style with explode i.e. tags=a&tags=b
+type FormCodec struct {
+	Notation   string
+	ArrayStyle QueryStyle
+}
+
+func (codec *FormCodec) ContentType() string {
+	return FormContentType
+}
+
+func (codec *FormCodec) Encode(value interface{}) ([]byte, error) {
+	values, err := codec.EncodeValues(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+func (codec *FormCodec) Decode(body io.Reader, target interface{}) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	return codec.DecodeValues(values, target)
+}
+
+// EncodeValues returns the form values of the model, null values are omitted
+func (codec *FormCodec) EncodeValues(value interface{}) (url.Values, error) {
+	if values, ok := value.(url.Values); ok {
+		return values, nil
+	}
+
+	normalized, err := normalizeJsonValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := normalized.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("a form can only be encoded from an object")
+	}
+
+	var result = url.Values{}
+	err = codec.encodeValue(result, "", object)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DecodeValues decodes the form values into the target, this is the counterpart of EncodeValues and is mostly useful
+// to inspect a form inside a test
+func (codec *FormCodec) DecodeValues(values url.Values, target interface{}) error {
+	var value = reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("the target must be a non nil pointer")
+	}
+
+	return codec.decodeValue(values, "", value.Elem())
+}
+
+func (codec *FormCodec) encodeValue(result url.Values, name string, value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		for _, key := range sortedJsonKeys(value) {
+			err := codec.encodeValue(result, codec.joinKey(name, key), value[key])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []interface{}:
+		if !isScalarList(value) {
+			for i, item := range value {
+				err := codec.encodeValue(result, name+"["+strconv.Itoa(i)+"]", item)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return addQueryValue(result, name, value, codec.getArrayStyle())
+}
+
+func (codec *FormCodec) decodeValue(values url.Values, name string, value reflect.Value) error {
+	if value.CanAddr() {
+		if _, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return codec.decodeScalar(values, name, value)
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !codec.hasValues(values, name) {
+			return nil
+		}
+
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return codec.decodeValue(values, name, value.Elem())
+	case reflect.Struct:
+		for _, field := range getSchemaFields(value.Type()) {
+			err := codec.decodeValue(values, codec.joinKey(name, field.name), value.FieldByIndex(field.index))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return &UnsupportedValueError{Type: value.Type()}
+		}
+
+		var keys = codec.getChildKeys(values, name)
+		if len(keys) == 0 {
+			return nil
+		}
+
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+
+		for _, key := range keys {
+			var item = reflect.New(value.Type().Elem()).Elem()
+			err := codec.decodeValue(values, codec.joinKey(name, key), item)
+			if err != nil {
+				return err
+			}
+
+			value.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), item)
+		}
+
+		return nil
+	case reflect.Slice:
+		if isScalarType(value.Type().Elem()) {
+			var items, ok = codec.getScalarList(values, name)
+			if !ok {
+				return nil
+			}
+
+			var slice = reflect.MakeSlice(value.Type(), len(items), len(items))
+			for i, item := range items {
+				err := setFormScalar(slice.Index(i), item)
+				if err != nil {
+					return err
+				}
+			}
+
+			value.Set(slice)
+
+			return nil
+		}
+
+		var slice = reflect.MakeSlice(value.Type(), 0, 0)
+		for i := 0; codec.hasValues(values, name+"["+strconv.Itoa(i)+"]"); i++ {
+			var item = reflect.New(value.Type().Elem()).Elem()
+			err := codec.decodeValue(values, name+"["+strconv.Itoa(i)+"]", item)
+			if err != nil {
+				return err
+			}
+
+			slice = reflect.Append(slice, item)
+		}
+
+		if slice.Len() > 0 {
+			value.Set(slice)
+		}
+
+		return nil
+	}
+
+	return codec.decodeScalar(values, name, value)
+}
+
+func (codec *FormCodec) decodeScalar(values url.Values, name string, value reflect.Value) error {
+	items, ok := values[name]
+	if !ok || len(items) == 0 {
+		return nil
+	}
+
+	return setFormScalar(value, items[0])
+}
+
+// getScalarList returns the values of an array of scalar values according to the array style
+func (codec *FormCodec) getScalarList(values url.Values, name string) ([]string, bool) {
+	var style = codec.getArrayStyle()
+	switch style.Style {
+	case QueryStyleDeepObject, QueryStyleBracket:
+		items, ok := values[name+"[]"]
+		return items, ok
+	}
+
+	items, ok := values[name]
+	if !ok || style.Explode {
+		return items, ok
+	}
+
+	if len(items) == 0 || items[0] == "" {
+		return []string{}, true
+	}
+
+	return strings.Split(items[0], style.getDelimiter()), true
+}
+
+// getChildKeys returns the sorted keys of all properties below the provided name
+func (codec *FormCodec) getChildKeys(values url.Values, name string) []string {
+	var found = make(map[string]bool)
+	for key := range values {
+		var rest = key
+		if name != "" {
+			var prefix = name + "["
+			if codec.Notation == FormNotationDot {
+				prefix = name + "."
+			}
+
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+
+			rest = key[len(prefix):]
+			if codec.Notation != FormNotationDot {
+				end := strings.Index(rest, "]")
+				if end == -1 {
+					continue
+				}
+
+				rest = rest[:end]
+			}
+		}
+
+		if end := strings.IndexAny(rest, ".["); end != -1 {
+			rest = rest[:end]
+		}
+
+		if rest != "" {
+			found[rest] = true
+		}
+	}
+
+	var keys = make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (codec *FormCodec) hasValues(values url.Values, name string) bool {
+	for key := range values {
+		if key == name || strings.HasPrefix(key, name+"[") || (codec.Notation == FormNotationDot && strings.HasPrefix(key, name+".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (codec *FormCodec) joinKey(name string, key string) string {
+	if name == "" {
+		return key
+	} else if codec.Notation == FormNotationDot {
+		return name + "." + key
+	}
+
+	return name + "[" + key + "]"
+}
+
+func (codec *FormCodec) getArrayStyle() QueryStyle {
+	if codec.ArrayStyle == (QueryStyle{}) {
+		return QueryStyle{Style: QueryStyleForm, Explode: true}
+	}
+
+	return codec.ArrayStyle
+}
+
+func NewFormCodec() *FormCodec {
+	return &FormCodec{Notation: FormNotationBracket}
+}
+
+func setFormScalar(value reflect.Value, text string) error {
+	if value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(text))
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(parsed)
+	case reflect.Ptr:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return setFormScalar(value.Elem(), text)
+	case reflect.Interface:
+		value.Set(reflect.ValueOf(text))
+	default:
+		return &UnsupportedValueError{Type: value.Type()}
+	}
+
+	return nil
+}
+
+func isScalarList(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isScalarType(valueType reflect.Type) bool {
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	if reflect.PtrTo(valueType).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch valueType.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
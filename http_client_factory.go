@@ -19,9 +19,43 @@ func HttpClientFactoryWithVersion(authenticator AuthenticatorInterface, version
 	}
 }
 
+func HttpClientFactoryWithOptions(authenticator AuthenticatorInterface, options ClientOptions) *http.Client {
+	var logging = options.Logging
+	if logging != nil {
+		logging = logging.withAuthenticator(authenticator)
+	}
+
+	return &http.Client{
+		Transport: &DefaultTransport{
+			Authenticator:   authenticator,
+			Version:         options.Version,
+			Transport:       options.Transport,
+			Compression:     options.Compression,
+			Logging:         logging,
+			Tracer:          options.Tracer,
+			Metrics:         options.Metrics,
+			Progress:        options.Progress,
+			MaxResponseSize: options.MaxResponseSize,
+		},
+	}
+}
+
 type DefaultTransport struct {
-	Authenticator AuthenticatorInterface
-	Version       string
+	Authenticator   AuthenticatorInterface
+	Version         string
+	Transport       http.RoundTripper
+	Compression     *Compression
+	Logging         *Logging
+	Tracer          TracerInterface
+	Metrics         MetricsInterface
+	Progress        ProgressFunc
+	MaxResponseSize int64
+}
+
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
 }
 
 func (transport *DefaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -30,12 +64,97 @@ func (transport *DefaultTransport) RoundTrip(req *http.Request) (*http.Response,
 	} else {
 		req.Header.Add("User-Agent", "SDKgen")
 	}
-	req.Header.Add("Accept", "application/json")
+	if req.Header.Get("Accept") == "" {
+		req.Header.Add("Accept", "application/json")
+	}
+
+	header, ok := getResponseHeader(req.Context())
+	if ok {
+		return captureResponseHeader(header, req, transport.observe)
+	}
 
+	return transport.observe(req)
+}
+
+func (transport *DefaultTransport) observe(req *http.Request) (*http.Response, error) {
+	if transport.Metrics != nil {
+		return observeRequest(transport.Metrics, req, transport.trace)
+	}
+
+	return transport.trace(req)
+}
+
+func (transport *DefaultTransport) trace(req *http.Request) (*http.Response, error) {
+	if transport.Tracer != nil {
+		return traceRequest(transport.Tracer, req, transport.intercept)
+	}
+
+	return transport.intercept(req)
+}
+
+func (transport *DefaultTransport) intercept(req *http.Request) (*http.Response, error) {
 	req, err := transport.Authenticator.Intercept(req)
 	if err != nil {
 		return nil, err
 	}
 
-	return http.DefaultTransport.RoundTrip(req)
+	if transport.Logging != nil {
+		return transport.Logging.Handle(req, transport.track)
+	}
+
+	return transport.track(req)
+}
+
+func (transport *DefaultTransport) track(req *http.Request) (*http.Response, error) {
+	progress, ok := GetProgress(req.Context())
+	if !ok {
+		progress = transport.Progress
+	}
+
+	if progress != nil {
+		return trackProgress(progress, req, transport.send)
+	}
+
+	return transport.send(req)
+}
+
+func (transport *DefaultTransport) send(req *http.Request) (*http.Response, error) {
+	if transport.MaxResponseSize > 0 {
+		return limitResponse(transport.MaxResponseSize, req, transport.compress)
+	}
+
+	return transport.compress(req)
+}
+
+func (transport *DefaultTransport) compress(req *http.Request) (*http.Response, error) {
+	if transport.Compression == nil {
+		return transport.next().RoundTrip(req)
+	}
+
+	req, err := transport.Compression.CompressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// setting the accept encoding header disables the automatic gzip handling of the http package so we decompress
+	// the response ourselves, this also works if the underlying transport has disabled compression
+	var acceptEncoding = transport.Compression.GetAcceptEncoding()
+	if acceptEncoding != "" && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	resp, err := transport.next().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return transport.Compression.DecompressResponse(resp)
+}
+
+func (transport *DefaultTransport) next() http.RoundTripper {
+	if transport.Transport != nil {
+		return transport.Transport
+	}
+
+	return http.DefaultTransport
 }
@@ -0,0 +1,642 @@
+package sdkgen
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const JsonPatchContentType = "application/json-patch+json"
+
+const (
+	JsonPatchAdd     = "add"
+	JsonPatchRemove  = "remove"
+	JsonPatchReplace = "replace"
+	JsonPatchMove    = "move"
+	JsonPatchCopy    = "copy"
+	JsonPatchTest    = "test"
+)
+
+// JsonPatchOperation represents a single operation of a JSON patch document according to RFC 6902
+type JsonPatchOperation struct {
+	Op    string
+	Path  string
+	From  string
+	Value interface{}
+}
+
+func (operation JsonPatchOperation) MarshalJSON() ([]byte, error) {
+	switch operation.Op {
+	case JsonPatchAdd, JsonPatchReplace, JsonPatchTest:
+		return json.Marshal(struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}{operation.Op, operation.Path, operation.Value})
+	case JsonPatchMove, JsonPatchCopy:
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			From string `json:"from"`
+			Path string `json:"path"`
+		}{operation.Op, operation.From, operation.Path})
+	}
+
+	return json.Marshal(struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+	}{operation.Op, operation.Path})
+}
+
+func (operation *JsonPatchOperation) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	err := json.Unmarshal(data, &members)
+	if err != nil {
+		return err
+	}
+
+	var result JsonPatchOperation
+	err = decodeJsonPatchMember(members, "op", &result.Op)
+	if err != nil {
+		return err
+	}
+
+	err = decodeJsonPatchMember(members, "path", &result.Path)
+	if err != nil {
+		return err
+	}
+
+	switch result.Op {
+	case JsonPatchAdd, JsonPatchReplace, JsonPatchTest:
+		raw, ok := members["value"]
+		if !ok {
+			return errors.New("the " + result.Op + " operation requires a value member")
+		}
+
+		result.Value, err = decodeJsonValue(raw)
+		if err != nil {
+			return err
+		}
+	case JsonPatchMove, JsonPatchCopy:
+		err = decodeJsonPatchMember(members, "from", &result.From)
+		if err != nil {
+			return err
+		}
+	case JsonPatchRemove:
+	default:
+		return errors.New("the operation " + result.Op + " is not supported")
+	}
+
+	*operation = result
+
+	return nil
+}
+
+// JsonPatch represents a JSON patch document, the methods append an operation and can be chained i.e.
+// NewJsonPatch().Replace("/name", "foo").Remove("/tags/0")
+type JsonPatch []JsonPatchOperation
+
+func (patch *JsonPatch) Add(path string, value interface{}) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchAdd, Path: path, Value: value})
+}
+
+func (patch *JsonPatch) Remove(path string) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchRemove, Path: path})
+}
+
+func (patch *JsonPatch) Replace(path string, value interface{}) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchReplace, Path: path, Value: value})
+}
+
+func (patch *JsonPatch) Move(from string, path string) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchMove, From: from, Path: path})
+}
+
+func (patch *JsonPatch) Copy(from string, path string) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchCopy, From: from, Path: path})
+}
+
+func (patch *JsonPatch) Test(path string, value interface{}) *JsonPatch {
+	return patch.append(JsonPatchOperation{Op: JsonPatchTest, Path: path, Value: value})
+}
+
+func (patch *JsonPatch) append(operation JsonPatchOperation) *JsonPatch {
+	*patch = append(*patch, operation)
+	return patch
+}
+
+func NewJsonPatch() *JsonPatch {
+	return &JsonPatch{}
+}
+
+// JsonPatchError is returned in case an operation of a JSON patch document could not be applied
+type JsonPatchError struct {
+	Index     int
+	Operation JsonPatchOperation
+	Message   string
+}
+
+func (e *JsonPatchError) Error() string {
+	return "could not apply operation " + strconv.Itoa(e.Index) + " (" + e.Operation.Op + " " + e.Operation.Path + "): " + e.Message
+}
+
+// JsonPointer returns a JSON pointer according to RFC 6901 which references the provided tokens
+func JsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(EscapeJsonPointer(token))
+	}
+
+	return pointer.String()
+}
+
+// EscapeJsonPointer escapes a reference token so that it can be used as part of a JSON pointer
+func EscapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// ParseJsonPointer returns the unescaped reference tokens of a JSON pointer, an empty pointer references the complete
+// document and returns no tokens
+func ParseJsonPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("a JSON pointer must start with a slash: " + pointer)
+	}
+
+	var tokens = strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 >= len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, errors.New("the JSON pointer contains an invalid escape sequence: " + pointer)
+			}
+		}
+
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// ApplyJsonPatch applies the JSON patch to the document, in case an operation fails a JsonPatchError is returned
+func ApplyJsonPatch(document []byte, patch JsonPatch) ([]byte, error) {
+	value, err := decodeJsonValue(document)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, operation := range patch {
+		value, err = applyJsonPatchOperation(value, operation)
+		if err != nil {
+			return nil, &JsonPatchError{Index: index, Operation: operation, Message: err.Error()}
+		}
+	}
+
+	return json.Marshal(value)
+}
+
+// CreateJsonPatch returns a JSON patch which transforms the original into the modified value, both values are
+// compared by their JSON representation so it is possible to pass i.e. two model structs
+func CreateJsonPatch(original interface{}, modified interface{}) (JsonPatch, error) {
+	originalValue, err := normalizeJsonValue(original)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedValue, err := normalizeJsonValue(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	var patch = JsonPatch{}
+	diffJsonPatch(&patch, "", originalValue, modifiedValue)
+
+	return patch, nil
+}
+
+func applyJsonPatchOperation(document interface{}, operation JsonPatchOperation) (interface{}, error) {
+	path, err := ParseJsonPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case JsonPatchAdd:
+		value, err := normalizeJsonValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return addJsonValue(document, path, value)
+	case JsonPatchRemove:
+		return removeJsonValue(document, path)
+	case JsonPatchReplace:
+		value, err := normalizeJsonValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return replaceJsonValue(document, path, value)
+	case JsonPatchMove:
+		from, err := ParseJsonPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		if operation.From == operation.Path {
+			_, err = getJsonValue(document, from)
+			return document, err
+		}
+
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, errors.New("a value can not be moved into one of its children")
+		}
+
+		value, err := getJsonValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err = removeJsonValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return addJsonValue(document, path, value)
+	case JsonPatchCopy:
+		from, err := ParseJsonPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := getJsonValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return addJsonValue(document, path, copyJsonValue(value))
+	case JsonPatchTest:
+		value, err := normalizeJsonValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		actual, err := getJsonValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !equalJsonValue(actual, value) {
+			return nil, errors.New("the value does not match")
+		}
+
+		return document, nil
+	}
+
+	return nil, errors.New("the operation " + operation.Op + " is not supported")
+}
+
+func getJsonValue(document interface{}, path []string) (interface{}, error) {
+	var value = document
+	for _, token := range path {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			child, ok := container[token]
+			if !ok {
+				return nil, errors.New("the path does not exist")
+			}
+
+			value = child
+		case []interface{}:
+			index, err := parseJsonArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			value = container[index]
+		default:
+			return nil, errors.New("the path does not exist")
+		}
+	}
+
+	return value, nil
+}
+
+func addJsonValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateJsonValue(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			var index = len(container)
+			if token != "-" {
+				var err error
+				index, err = parseJsonArrayIndex(token, len(container))
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+
+			return container, nil
+		}
+
+		return nil, errors.New("the path does not exist")
+	})
+}
+
+func removeJsonValue(document interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("the document root can not be removed")
+	}
+
+	return updateJsonValue(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, errors.New("the path does not exist")
+			}
+
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, err := parseJsonArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			return append(container[:index:index], container[index+1:]...), nil
+		}
+
+		return nil, errors.New("the path does not exist")
+	})
+}
+
+func replaceJsonValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateJsonValue(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, errors.New("the path does not exist")
+			}
+
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index, err := parseJsonArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			container[index] = value
+			return container, nil
+		}
+
+		return nil, errors.New("the path does not exist")
+	})
+}
+
+// updateJsonValue walks to the parent of the referenced location and passes the parent and the last token to the
+// update function, the returned parent replaces the existing parent since an array may be reallocated
+func updateJsonValue(value interface{}, path []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(value, path[0])
+	}
+
+	switch container := value.(type) {
+	case map[string]interface{}:
+		child, ok := container[path[0]]
+		if !ok {
+			return nil, errors.New("the path does not exist")
+		}
+
+		child, err := updateJsonValue(child, path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+
+		container[path[0]] = child
+		return container, nil
+	case []interface{}:
+		index, err := parseJsonArrayIndex(path[0], len(container)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := updateJsonValue(container[index], path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+
+		container[index] = child
+		return container, nil
+	}
+
+	return nil, errors.New("the path does not exist")
+}
+
+func parseJsonArrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, errors.New("the array index is invalid: " + token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index > max {
+		return 0, errors.New("the array index is out of bounds: " + token)
+	}
+
+	return index, nil
+}
+
+func diffJsonPatch(patch *JsonPatch, path string, original interface{}, modified interface{}) {
+	if equalJsonValue(original, modified) {
+		return
+	}
+
+	switch originalValue := original.(type) {
+	case map[string]interface{}:
+		if modifiedValue, ok := modified.(map[string]interface{}); ok {
+			diffJsonObject(patch, path, originalValue, modifiedValue)
+			return
+		}
+	case []interface{}:
+		if modifiedValue, ok := modified.([]interface{}); ok {
+			diffJsonArray(patch, path, originalValue, modifiedValue)
+			return
+		}
+	}
+
+	patch.Replace(path, modified)
+}
+
+func diffJsonObject(patch *JsonPatch, path string, original map[string]interface{}, modified map[string]interface{}) {
+	for _, name := range sortedJsonKeys(original) {
+		if _, ok := modified[name]; !ok {
+			patch.Remove(path + "/" + EscapeJsonPointer(name))
+		}
+	}
+
+	for _, name := range sortedJsonKeys(modified) {
+		originalValue, ok := original[name]
+		if !ok {
+			patch.Add(path+"/"+EscapeJsonPointer(name), modified[name])
+		} else {
+			diffJsonPatch(patch, path+"/"+EscapeJsonPointer(name), originalValue, modified[name])
+		}
+	}
+}
+
+// diffJsonArray skips the common prefix and suffix of both arrays, the remaining elements are compared by index and
+// the difference in length is removed or added so that a single inserted or removed element results in one operation
+func diffJsonArray(patch *JsonPatch, path string, original []interface{}, modified []interface{}) {
+	var prefix = 0
+	for prefix < len(original) && prefix < len(modified) && equalJsonValue(original[prefix], modified[prefix]) {
+		prefix++
+	}
+
+	var suffix = 0
+	for suffix < len(original)-prefix && suffix < len(modified)-prefix && equalJsonValue(original[len(original)-1-suffix], modified[len(modified)-1-suffix]) {
+		suffix++
+	}
+
+	var originalItems = original[prefix : len(original)-suffix]
+	var modifiedItems = modified[prefix : len(modified)-suffix]
+
+	var common = len(originalItems)
+	if len(modifiedItems) < common {
+		common = len(modifiedItems)
+	}
+
+	for i := 0; i < common; i++ {
+		diffJsonPatch(patch, path+"/"+strconv.Itoa(prefix+i), originalItems[i], modifiedItems[i])
+	}
+
+	for i := common; i < len(originalItems); i++ {
+		patch.Remove(path + "/" + strconv.Itoa(prefix+common))
+	}
+
+	for i := common; i < len(modifiedItems); i++ {
+		patch.Add(path+"/"+strconv.Itoa(prefix+i), modifiedItems[i])
+	}
+}
+
+func equalJsonValue(left interface{}, right interface{}) bool {
+	switch leftValue := left.(type) {
+	case map[string]interface{}:
+		rightValue, ok := right.(map[string]interface{})
+		if !ok || len(leftValue) != len(rightValue) {
+			return false
+		}
+
+		for name, value := range leftValue {
+			other, ok := rightValue[name]
+			if !ok || !equalJsonValue(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		rightValue, ok := right.([]interface{})
+		if !ok || len(leftValue) != len(rightValue) {
+			return false
+		}
+
+		for i := range leftValue {
+			if !equalJsonValue(leftValue[i], rightValue[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		rightValue, ok := right.(json.Number)
+		if !ok {
+			return false
+		}
+
+		if leftValue == rightValue {
+			return true
+		}
+
+		leftNumber, _, errLeft := big.ParseFloat(leftValue.String(), 10, 256, big.ToNearestEven)
+		rightNumber, _, errRight := big.ParseFloat(rightValue.String(), 10, 256, big.ToNearestEven)
+
+		return errLeft == nil && errRight == nil && leftNumber.Cmp(rightNumber) == 0
+	}
+
+	return left == right
+}
+
+func copyJsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		var result = make(map[string]interface{}, len(value))
+		for name, item := range value {
+			result[name] = copyJsonValue(item)
+		}
+
+		return result
+	case []interface{}:
+		var result = make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = copyJsonValue(item)
+		}
+
+		return result
+	}
+
+	return value
+}
+
+// normalizeJsonValue converts an arbitrary value into the generic representation of its JSON encoding
+func normalizeJsonValue(value interface{}) (interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJsonValue(raw)
+}
+
+func sortedJsonKeys(object map[string]interface{}) []string {
+	var keys = make([]string, 0, len(object))
+	for name := range object {
+		keys = append(keys, name)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func decodeJsonPatchMember(members map[string]json.RawMessage, name string, value *string) error {
+	raw, ok := members[name]
+	if !ok {
+		return errors.New("the operation requires a " + name + " member")
+	}
+
+	err := json.Unmarshal(raw, value)
+	if err != nil || string(raw) == "null" {
+		return errors.New("the " + name + " member must be a string")
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package sdkgen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Link is a single web link according to RFC 8288, the relation may contain multiple space separated relation types.
+// All parameters which are not known are available through Params
+type Link struct {
+	Href      string
+	Rel       string
+	Type      string
+	Title     string
+	Hreflang  string
+	Anchor    string
+	Media     string
+	Name      string
+	Templated bool
+	Params    map[string]string
+}
+
+func (link Link) GetRels() []string {
+	return strings.Fields(link.Rel)
+}
+
+func (link Link) HasRel(rel string) bool {
+	for _, value := range link.GetRels() {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Links []Link
+
+// Get returns the first link with the provided relation type
+func (links Links) Get(rel string) (Link, bool) {
+	for _, link := range links {
+		if link.HasRel(rel) {
+			return link, true
+		}
+	}
+
+	return Link{}, false
+}
+
+func (links Links) GetAll(rel string) Links {
+	var result Links
+	for _, link := range links {
+		if link.HasRel(rel) {
+			result = append(result, link)
+		}
+	}
+
+	return result
+}
+
+// GetLinks returns all links of the Link headers
+func GetLinks(header http.Header) Links {
+	return ParseLinkHeader(header.Values("Link")...)
+}
+
+// ParseLinkHeader parses the values of a Link header, invalid links are skipped
+func ParseLinkHeader(values ...string) Links {
+	var links Links
+	for _, value := range values {
+		var parser = &linkHeaderParser{value: value}
+		links = append(links, parser.parse()...)
+	}
+
+	return links
+}
+
+// ParseHalLinks returns all links of the _links property of a HAL document, a relation can contain a single link
+// object or an array of link objects
+func ParseHalLinks(data []byte) (Links, error) {
+	var document struct {
+		Links map[string]json.RawMessage `json:"_links"`
+	}
+
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels = make([]string, 0, len(document.Links))
+	for rel := range document.Links {
+		rels = append(rels, rel)
+	}
+
+	sort.Strings(rels)
+
+	var links Links
+	for _, rel := range rels {
+		var raw = document.Links[rel]
+
+		var objects []halLink
+		if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+			err = json.Unmarshal(raw, &objects)
+		} else {
+			var object halLink
+			err = json.Unmarshal(raw, &object)
+			objects = append(objects, object)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			links = append(links, Link{
+				Href:      object.Href,
+				Rel:       rel,
+				Type:      object.Type,
+				Title:     object.Title,
+				Hreflang:  object.Hreflang,
+				Name:      object.Name,
+				Templated: object.Templated,
+			})
+		}
+	}
+
+	return links, nil
+}
+
+// Follow requests the link through the authenticated http client of the client, a relative link is resolved against
+// the base url of the parser. A link to a different origin is only followed if the context was created through
+// WithCrossOrigin since the request contains the credentials of the client. The caller is responsible to close the
+// body of the response
+func (client *ClientAbstract) Follow(ctx context.Context, link Link) (*http.Response, error) {
+	if link.Templated {
+		return nil, errors.New("a templated link can not be followed")
+	}
+
+	target, err := client.ResolveLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(client.Parser.BaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkOrigin(ctx, base, target)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.Type != "" {
+		req.Header.Set("Accept", link.Type)
+	}
+
+	return client.HttpClient.Do(req)
+}
+
+// ResolveLink returns the absolute url of the link, a relative link is resolved against the base url of the parser
+func (client *ClientAbstract) ResolveLink(link Link) (string, error) {
+	base, err := url.Parse(client.Parser.BaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	// like at the Url method of the parser all relative paths are located below the base url
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+
+	target, err := base.Parse(link.Href)
+	if err != nil {
+		return "", err
+	}
+
+	return target.String(), nil
+}
+
+// FollowLink requests the link and decodes the JSON response
+func FollowLink[T any](ctx context.Context, client *ClientAbstract, link Link) (T, error) {
+	var data T
+
+	resp, err := client.Follow(ctx, link)
+	if err != nil {
+		return data, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return data, errors.New("the server returned an unexpected status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	err = client.Parser.Decode(resp.Body, &data)
+
+	return data, err
+}
+
+// CrossOriginError is returned in case a link points to a different origin than the url where it was obtained from
+type CrossOriginError struct {
+	Url string
+}
+
+func (e *CrossOriginError) Error() string {
+	return "the link " + e.Url + " points to a different origin, use WithCrossOrigin to follow it"
+}
+
+type crossOriginKey struct{}
+
+// WithCrossOrigin returns a context which allows to follow links to a different origin, note that the request to the
+// other origin contains the credentials of the client
+func WithCrossOrigin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossOriginKey{}, true)
+}
+
+func checkOrigin(ctx context.Context, origin *url.URL, target string) error {
+	allowed, _ := ctx.Value(crossOriginKey{}).(bool)
+	if allowed {
+		return nil
+	}
+
+	targetUrl, err := url.Parse(target)
+	if err != nil {
+		return err
+	}
+
+	if getOrigin(origin) != getOrigin(targetUrl) {
+		return &CrossOriginError{Url: target}
+	}
+
+	return nil
+}
+
+func getOrigin(u *url.URL) string {
+	var scheme = strings.ToLower(u.Scheme)
+	var port = u.Port()
+	if port == "" && scheme == "https" {
+		port = "443"
+	} else if port == "" && scheme == "http" {
+		port = "80"
+	}
+
+	return scheme + "://" + strings.ToLower(u.Hostname()) + ":" + port
+}
+
+type halLink struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Hreflang  string `json:"hreflang"`
+}
+
+type linkHeaderParser struct {
+	value string
+	pos   int
+}
+
+func (parser *linkHeaderParser) parse() Links {
+	var links Links
+	for {
+		parser.skip(" \t,")
+		if parser.pos >= len(parser.value) {
+			return links
+		}
+
+		if parser.value[parser.pos] != '<' {
+			parser.skipLink()
+			continue
+		}
+
+		end := strings.IndexByte(parser.value[parser.pos:], '>')
+		if end == -1 {
+			return links
+		}
+
+		var link = Link{Href: strings.TrimSpace(parser.value[parser.pos+1 : parser.pos+end])}
+		var title = ""
+		parser.pos += end + 1
+
+		for {
+			parser.skip(" \t")
+			if parser.pos >= len(parser.value) || parser.value[parser.pos] != ';' {
+				break
+			}
+
+			parser.pos++
+			parser.skip(" \t")
+
+			var name = strings.ToLower(parser.readToken())
+			var value = ""
+			parser.skip(" \t")
+			if parser.pos < len(parser.value) && parser.value[parser.pos] == '=' {
+				parser.pos++
+				parser.skip(" \t")
+				value = parser.readValue()
+			}
+
+			if name == "" {
+				continue
+			}
+
+			switch name {
+			case "rel":
+				// only the first rel parameter is used
+				if link.Rel == "" {
+					link.Rel = value
+				}
+			case "anchor":
+				link.Anchor = value
+			case "type":
+				link.Type = value
+			case "title":
+				link.Title = value
+			case "title*":
+				title = decodeExtValue(value)
+			case "hreflang":
+				link.Hreflang = value
+			case "media":
+				link.Media = value
+			default:
+				if link.Params == nil {
+					link.Params = make(map[string]string)
+				}
+
+				link.Params[name] = value
+			}
+		}
+
+		if title != "" {
+			link.Title = title
+		}
+
+		links = append(links, link)
+		parser.skipLink()
+	}
+}
+
+func (parser *linkHeaderParser) readToken() string {
+	var start = parser.pos
+	for parser.pos < len(parser.value) && !strings.ContainsRune("=;, \t\"", rune(parser.value[parser.pos])) {
+		parser.pos++
+	}
+
+	return parser.value[start:parser.pos]
+}
+
+func (parser *linkHeaderParser) readValue() string {
+	if parser.pos >= len(parser.value) || parser.value[parser.pos] != '"' {
+		return parser.readToken()
+	}
+
+	parser.pos++
+
+	var value strings.Builder
+	for parser.pos < len(parser.value) {
+		var char = parser.value[parser.pos]
+		parser.pos++
+
+		if char == '\\' && parser.pos < len(parser.value) {
+			value.WriteByte(parser.value[parser.pos])
+			parser.pos++
+		} else if char == '"' {
+			break
+		} else {
+			value.WriteByte(char)
+		}
+	}
+
+	return value.String()
+}
+
+// skipLink moves the position to the next comma which is not part of a quoted string
+func (parser *linkHeaderParser) skipLink() {
+	var quoted = false
+	for parser.pos < len(parser.value) {
+		var char = parser.value[parser.pos]
+		if char == '\\' && quoted {
+			parser.pos++
+		} else if char == '"' {
+			quoted = !quoted
+		} else if char == ',' && !quoted {
+			return
+		}
+
+		parser.pos++
+	}
+}
+
+func (parser *linkHeaderParser) skip(chars string) {
+	for parser.pos < len(parser.value) && strings.IndexByte(chars, parser.value[parser.pos]) != -1 {
+		parser.pos++
+	}
+}
+
+// decodeExtValue decodes an extended parameter value according to RFC 8187 i.e. UTF-8'de'n%c3%a4chstes%20Kapitel
+func decodeExtValue(value string) string {
+	var parts = strings.SplitN(value, "'", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[0], "UTF-8") {
+		return ""
+	}
+
+	decoded, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return ""
+	}
+
+	return decoded
+}
@@ -0,0 +1,385 @@
+package sdkgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// LoggerInterface receives a message and structured fields, it can be easily adapted to log/slog or any other
+// structured logging library
+type LoggerInterface interface {
+	Log(message string, fields []LogField)
+}
+
+type StdLogger struct {
+	Logger *log.Logger
+}
+
+func (logger *StdLogger) Log(message string, fields []LogField) {
+	var line = message
+	for _, field := range fields {
+		var value = fmt.Sprint(field.Value)
+		if value == "" || strings.ContainsAny(value, " \t\r\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		line += " " + field.Key + "=" + value
+	}
+
+	logger.Logger.Print(line)
+}
+
+func NewStdLogger(logger *log.Logger) *StdLogger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &StdLogger{Logger: logger}
+}
+
+// Logging logs every request which is sent through the DefaultTransport. Sensitive header values, query parameters
+// and JSON or form fields are redacted, the default lists are extended by RedactHeaders and RedactFields
+type Logging struct {
+	Logger        LoggerInterface
+	Headers       bool
+	Body          bool
+	MaxBodySize   int
+	RedactHeaders []string
+	RedactFields  []string
+}
+
+func (logging *Logging) Handle(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	var start = time.Now()
+
+	var requestBody *capturingReader
+	if req.Body != nil && req.Body != http.NoBody {
+		requestBody = &capturingReader{reader: req.Body, limit: logging.getMaxBodySize(), capture: logging.Body}
+		req = req.Clone(req.Context())
+		req.Body = requestBody
+	}
+
+	resp, err := next(req)
+
+	var fields = []LogField{
+		{Key: "method", Value: req.Method},
+		{Key: "url", Value: logging.RedactUrl(req.URL)},
+	}
+
+	if operation, ok := GetOperation(req.Context()); ok {
+		fields = append(fields, LogField{Key: "operation", Value: operation.GetFullName()})
+	}
+
+	if resp != nil {
+		fields = append(fields, LogField{Key: "status", Value: resp.StatusCode})
+	}
+
+	fields = append(fields, LogField{Key: "duration", Value: time.Since(start).String()})
+	fields = append(fields, LogField{Key: "attempt", Value: GetAttempt(req.Context())})
+
+	if requestBody != nil {
+		fields = append(fields, LogField{Key: "request_size", Value: requestBody.getSize()})
+	} else {
+		fields = append(fields, LogField{Key: "request_size", Value: 0})
+	}
+
+	if resp != nil && resp.ContentLength >= 0 {
+		fields = append(fields, LogField{Key: "response_size", Value: resp.ContentLength})
+	}
+
+	if logging.Headers {
+		fields = append(fields, LogField{Key: "request_headers", Value: logging.RedactHeader(req.Header)})
+		if resp != nil {
+			fields = append(fields, LogField{Key: "response_headers", Value: logging.RedactHeader(resp.Header)})
+		}
+	}
+
+	if logging.Body && requestBody != nil {
+		fields = append(fields, LogField{Key: "request_body", Value: requestBody.redact(logging, req.Header.Get("Content-Type"))})
+	}
+
+	if logging.Body && err == nil && resp != nil && resp.Body != nil && resp.Body != http.NoBody {
+		// the response body is captured while the caller reads it so that a stream is not blocked, the entry is logged
+		// once the body was read completely or closed
+		var responseBody = &capturingReader{reader: resp.Body, limit: logging.getMaxBodySize(), capture: true}
+		var contentType = resp.Header.Get("Content-Type")
+		resp.Body = &loggingBody{capturingReader: responseBody, done: func() {
+			logging.Logger.Log("sdkgen request", append(fields, LogField{Key: "response_body", Value: responseBody.redact(logging, contentType)}))
+		}}
+
+		return resp, err
+	}
+
+	if err != nil {
+		fields = append(fields, LogField{Key: "error", Value: err.Error()})
+	}
+
+	logging.Logger.Log("sdkgen request", fields)
+
+	return resp, err
+}
+
+func (logging *Logging) RedactHeader(header http.Header) string {
+	var names []string
+	for name := range header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		var value = strings.Join(header.Values(name), ", ")
+		if logging.isSensitiveHeader(name) {
+			value = redacted
+		}
+
+		parts = append(parts, name+": "+value)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func (logging *Logging) RedactUrl(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	var query = u.Query()
+	for name := range query {
+		if logging.isSensitiveField(name) {
+			query.Set(name, redacted)
+		}
+	}
+
+	var redactedUrl = *u
+	redactedUrl.RawQuery = query.Encode()
+
+	return redactedUrl.String()
+}
+
+func (logging *Logging) RedactBody(contentType string, body []byte, truncated bool) string {
+	var result = string(body)
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		var data interface{}
+		if json.Unmarshal(body, &data) == nil {
+			raw, err := json.Marshal(logging.redactValue(data))
+			if err == nil {
+				result = string(raw)
+			}
+		} else if truncated {
+			// a truncated JSON document can not be parsed so we can not guarantee that it contains no secrets
+			result = redacted
+		}
+	} else if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			for name := range values {
+				if logging.isSensitiveField(name) {
+					values.Set(name, redacted)
+				}
+			}
+
+			result = values.Encode()
+		}
+	}
+
+	if truncated {
+		result += "...(truncated)"
+	}
+
+	return result
+}
+
+func (logging *Logging) redactValue(value interface{}) interface{} {
+	switch data := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range data {
+			if logging.isSensitiveField(key) {
+				data[key] = redacted
+			} else {
+				data[key] = logging.redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for index, nested := range data {
+			data[index] = logging.redactValue(nested)
+		}
+	}
+
+	return value
+}
+
+func (logging *Logging) isSensitiveHeader(name string) bool {
+	for _, sensitive := range []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"} {
+		if strings.EqualFold(sensitive, name) {
+			return true
+		}
+	}
+
+	for _, sensitive := range logging.RedactHeaders {
+		if strings.EqualFold(sensitive, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (logging *Logging) isSensitiveField(name string) bool {
+	for _, sensitive := range []string{"access_token", "refresh_token", "client_secret", "password"} {
+		if strings.EqualFold(sensitive, name) {
+			return true
+		}
+	}
+
+	for _, sensitive := range logging.RedactFields {
+		if strings.EqualFold(sensitive, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (logging *Logging) getMaxBodySize() int {
+	if logging.MaxBodySize > 0 {
+		return logging.MaxBodySize
+	}
+
+	return 4096
+}
+
+// withAuthenticator returns a copy of the logging config which additionally redacts the header of an API key
+// authenticator
+func (logging *Logging) withAuthenticator(authenticator AuthenticatorInterface) *Logging {
+	apiKey, ok := authenticator.(*ApiKeyAuthenticator)
+	if !ok || apiKey.Credentials.Name == "" {
+		return logging
+	}
+
+	var result = *logging
+	result.RedactHeaders = append([]string{apiKey.Credentials.Name}, logging.RedactHeaders...)
+
+	return &result
+}
+
+func NewLogging(logger LoggerInterface) *Logging {
+	return &Logging{
+		Logger: logger,
+	}
+}
+
+type attemptKey struct{}
+
+// WithAttempt stores the attempt number of a request at the context so that logging, tracing and metrics can
+// distinguish the attempts, the EventSource sets it on every reconnect and a custom transport which retries a request
+// should set it on the cloned request before it calls the DefaultTransport
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func GetAttempt(ctx context.Context) int {
+	attempt, ok := ctx.Value(attemptKey{}).(int)
+	if !ok {
+		return 1
+	}
+
+	return attempt
+}
+
+// the transport may still read the request body in the background after the response has arrived, because of this
+// the captured data is protected by a mutex
+type capturingReader struct {
+	mutex     sync.Mutex
+	reader    io.ReadCloser
+	limit     int
+	capture   bool
+	buffer    bytes.Buffer
+	size      int64
+	truncated bool
+}
+
+func (reader *capturingReader) Read(p []byte) (int, error) {
+	n, err := reader.reader.Read(p)
+
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	reader.size += int64(n)
+
+	if reader.capture && n > 0 {
+		var remaining = reader.limit - reader.buffer.Len()
+		if remaining >= n {
+			reader.buffer.Write(p[:n])
+		} else {
+			if remaining > 0 {
+				reader.buffer.Write(p[:remaining])
+			}
+			reader.truncated = true
+		}
+	}
+
+	return n, err
+}
+
+func (reader *capturingReader) getSize() int64 {
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	return reader.size
+}
+
+func (reader *capturingReader) redact(logging *Logging, contentType string) string {
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	return logging.RedactBody(contentType, reader.buffer.Bytes(), reader.truncated)
+}
+
+func (reader *capturingReader) Close() error {
+	return reader.reader.Close()
+}
+
+// loggingBody calls the done function once the body was read until EOF or closed
+type loggingBody struct {
+	*capturingReader
+	once sync.Once
+	done func()
+}
+
+func (body *loggingBody) Read(p []byte) (int, error) {
+	n, err := body.capturingReader.Read(p)
+	if err == io.EOF {
+		body.once.Do(body.done)
+	}
+
+	return n, err
+}
+
+func (body *loggingBody) Close() error {
+	err := body.capturingReader.Close()
+	body.once.Do(body.done)
+
+	return err
+}
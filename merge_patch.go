@@ -0,0 +1,144 @@
+package sdkgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+const MergePatchContentType = "application/merge-patch+json"
+
+// ApplyMergePatch applies the JSON merge patch to the target document according to RFC 7396
+func ApplyMergePatch(target []byte, patch []byte) ([]byte, error) {
+	targetValue, err := decodeJsonValue(target)
+	if err != nil {
+		return nil, err
+	}
+
+	patchValue, err := decodeJsonValue(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(targetValue, patchValue))
+}
+
+// CreateMergePatch returns a JSON merge patch which transforms the original into the modified document. Since a merge
+// patch can not express a null value inside an object, such values are removed by the patch
+func CreateMergePatch(original []byte, modified []byte) ([]byte, error) {
+	originalValue, err := decodeJsonValue(original)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedValue, err := decodeJsonValue(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(diffMergePatch(originalValue, modifiedValue))
+}
+
+// UnmarshalPatch decodes a JSON merge patch into the target struct. Since encoding/json sets a pointer to nil in case
+// the JSON contains null, a field which is declared as pointer to a Nullable is afterwards set to Null in case the patch
+// contains an explicit null, so that an absent field (nil) and null can be distinguished. A generated patch model calls
+// this function in its UnmarshalJSON method
+func UnmarshalPatch(data []byte, target interface{}) error {
+	err := json.Unmarshal(data, target)
+	if err != nil {
+		return err
+	}
+
+	var value = reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	var properties map[string]json.RawMessage
+	err = json.Unmarshal(data, &properties)
+	if err != nil {
+		return err
+	}
+
+	var fields = getSchemaFields(value.Elem().Type())
+	for name, raw := range properties {
+		field, ok := findSchemaField(fields, name)
+		if !ok || string(bytes.TrimSpace(raw)) != "null" {
+			continue
+		}
+
+		var fieldValue = value.Elem().FieldByIndex(field.index)
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Implements(optionalValueType) && fieldValue.CanSet() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+	}
+
+	return nil
+}
+
+var optionalValueType = reflect.TypeOf((*optionalValue)(nil)).Elem()
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+		} else {
+			targetObject[name] = mergePatch(targetObject[name], value)
+		}
+	}
+
+	return targetObject
+}
+
+func diffMergePatch(original interface{}, modified interface{}) interface{} {
+	originalObject, ok := original.(map[string]interface{})
+	if !ok {
+		return modified
+	}
+
+	modifiedObject, ok := modified.(map[string]interface{})
+	if !ok {
+		return modified
+	}
+
+	var patch = make(map[string]interface{})
+	for name := range originalObject {
+		if _, ok := modifiedObject[name]; !ok {
+			patch[name] = nil
+		}
+	}
+
+	for name, value := range modifiedObject {
+		originalValue, ok := originalObject[name]
+		if !ok {
+			patch[name] = value
+		} else if !reflect.DeepEqual(originalValue, value) {
+			patch[name] = diffMergePatch(originalValue, value)
+		}
+	}
+
+	return patch
+}
+
+func decodeJsonValue(data []byte) (interface{}, error) {
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
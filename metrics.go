@@ -0,0 +1,269 @@
+package sdkgen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsInterface receives a measurement for every request which is sent through the DefaultTransport, the
+// operation is empty in case the request was not created by a generated method
+type MetricsInterface interface {
+	ObserveRequest(operation Operation, statusCode int, duration time.Duration, err error)
+	ObserveRetry(operation Operation, attempt int)
+	ObserveTokenRefresh(grantType string)
+}
+
+func observeRequest(metrics MetricsInterface, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	operation, _ := GetOperation(req.Context())
+
+	if attempt := GetAttempt(req.Context()); attempt > 1 {
+		metrics.ObserveRetry(operation, attempt)
+	}
+
+	var start = time.Now()
+	resp, err := next(req)
+
+	var statusCode = 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+
+	metrics.ObserveRequest(operation, statusCode, time.Since(start), err)
+
+	return resp, err
+}
+
+// GetStatusClass returns the class of a status code i.e. 4xx or transport in case no response was received
+func GetStatusClass(statusCode int, err error) string {
+	if err != nil || statusCode < 100 || statusCode > 599 {
+		return "transport"
+	}
+
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+type metricsKey struct {
+	tag  string
+	name string
+}
+
+type operationMetrics struct {
+	operation Operation
+	requests  int64
+	errors    map[string]int64
+	retries   int64
+	buckets   []int64
+	sum       float64
+}
+
+// MemoryMetrics aggregates all measurements in memory, the metrics can be exposed in the Prometheus text format
+// through WritePrometheus or by using the metrics as http.Handler
+type MemoryMetrics struct {
+	buckets        []float64
+	operations     map[metricsKey]*operationMetrics
+	tokenRefreshes map[string]int64
+	mutex          sync.Mutex
+}
+
+func (metrics *MemoryMetrics) ObserveRequest(operation Operation, statusCode int, duration time.Duration, err error) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	var entry = metrics.getOperation(operation)
+	entry.requests++
+
+	var statusClass = GetStatusClass(statusCode, err)
+	if statusClass == "transport" || statusCode >= 400 {
+		entry.errors[statusClass]++
+	}
+
+	var seconds = duration.Seconds()
+	entry.sum += seconds
+	for index, bound := range metrics.buckets {
+		if seconds <= bound {
+			entry.buckets[index]++
+		}
+	}
+}
+
+func (metrics *MemoryMetrics) ObserveRetry(operation Operation, attempt int) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.getOperation(operation).retries++
+}
+
+func (metrics *MemoryMetrics) ObserveTokenRefresh(grantType string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.tokenRefreshes[grantType]++
+}
+
+func (metrics *MemoryMetrics) GetRequestCount(operation Operation) int64 {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	entry, ok := metrics.operations[metricsKey{tag: operation.Tag, name: operation.Name}]
+	if !ok {
+		return 0
+	}
+
+	return entry.requests
+}
+
+func (metrics *MemoryMetrics) GetErrorCount(operation Operation, statusClass string) int64 {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	entry, ok := metrics.operations[metricsKey{tag: operation.Tag, name: operation.Name}]
+	if !ok {
+		return 0
+	}
+
+	return entry.errors[statusClass]
+}
+
+func (metrics *MemoryMetrics) GetRetryCount(operation Operation) int64 {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	entry, ok := metrics.operations[metricsKey{tag: operation.Tag, name: operation.Name}]
+	if !ok {
+		return 0
+	}
+
+	return entry.retries
+}
+
+func (metrics *MemoryMetrics) GetTokenRefreshCount(grantType string) int64 {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	return metrics.tokenRefreshes[grantType]
+}
+
+func (metrics *MemoryMetrics) WritePrometheus(writer io.Writer) error {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	var operations []*operationMetrics
+	for _, entry := range metrics.operations {
+		operations = append(operations, entry)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].operation.GetFullName() < operations[j].operation.GetFullName()
+	})
+
+	var buffer = bufio.NewWriter(writer)
+
+	fmt.Fprintln(buffer, "# HELP sdkgen_requests_total Total number of requests.")
+	fmt.Fprintln(buffer, "# TYPE sdkgen_requests_total counter")
+	for _, entry := range operations {
+		fmt.Fprintf(buffer, "sdkgen_requests_total{%s} %d\n", operationLabels(entry.operation), entry.requests)
+	}
+
+	fmt.Fprintln(buffer, "# HELP sdkgen_request_errors_total Total number of failed requests by status class.")
+	fmt.Fprintln(buffer, "# TYPE sdkgen_request_errors_total counter")
+	for _, entry := range operations {
+		var errors = entry.errors
+
+		var classes []string
+		for statusClass := range errors {
+			classes = append(classes, statusClass)
+		}
+
+		sort.Strings(classes)
+
+		for _, statusClass := range classes {
+			fmt.Fprintf(buffer, "sdkgen_request_errors_total{%s,status_class=\"%s\"} %d\n", operationLabels(entry.operation), statusClass, errors[statusClass])
+		}
+	}
+
+	fmt.Fprintln(buffer, "# HELP sdkgen_request_retries_total Total number of retried requests.")
+	fmt.Fprintln(buffer, "# TYPE sdkgen_request_retries_total counter")
+	for _, entry := range operations {
+		fmt.Fprintf(buffer, "sdkgen_request_retries_total{%s} %d\n", operationLabels(entry.operation), entry.retries)
+	}
+
+	fmt.Fprintln(buffer, "# HELP sdkgen_request_duration_seconds Request latency in seconds.")
+	fmt.Fprintln(buffer, "# TYPE sdkgen_request_duration_seconds histogram")
+	for _, entry := range operations {
+		var labels = operationLabels(entry.operation)
+		for index, bound := range metrics.buckets {
+			fmt.Fprintf(buffer, "sdkgen_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, strconv.FormatFloat(bound, 'g', -1, 64), entry.buckets[index])
+		}
+
+		fmt.Fprintf(buffer, "sdkgen_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, entry.requests)
+		fmt.Fprintf(buffer, "sdkgen_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(entry.sum, 'g', -1, 64))
+		fmt.Fprintf(buffer, "sdkgen_request_duration_seconds_count{%s} %d\n", labels, entry.requests)
+	}
+
+	var grantTypes []string
+	for grantType := range metrics.tokenRefreshes {
+		grantTypes = append(grantTypes, grantType)
+	}
+
+	sort.Strings(grantTypes)
+
+	fmt.Fprintln(buffer, "# HELP sdkgen_token_refreshes_total Total number of successful access token refreshes and client credentials fetches.")
+	fmt.Fprintln(buffer, "# TYPE sdkgen_token_refreshes_total counter")
+	for _, grantType := range grantTypes {
+		fmt.Fprintf(buffer, "sdkgen_token_refreshes_total{grant_type=\"%s\"} %d\n", escapeLabel(grantType), metrics.tokenRefreshes[grantType])
+	}
+
+	return buffer.Flush()
+}
+
+func (metrics *MemoryMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = metrics.WritePrometheus(w)
+}
+
+func (metrics *MemoryMetrics) getOperation(operation Operation) *operationMetrics {
+	var key = metricsKey{tag: operation.Tag, name: operation.Name}
+
+	entry, ok := metrics.operations[key]
+	if !ok {
+		entry = &operationMetrics{
+			operation: operation,
+			errors:    make(map[string]int64),
+			buckets:   make([]int64, len(metrics.buckets)),
+		}
+
+		metrics.operations[key] = entry
+	}
+
+	return entry
+}
+
+func NewMemoryMetrics() *MemoryMetrics {
+	return NewMemoryMetricsWithBuckets([]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10})
+}
+
+func NewMemoryMetricsWithBuckets(buckets []float64) *MemoryMetrics {
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &MemoryMetrics{
+		buckets:        buckets,
+		operations:     make(map[metricsKey]*operationMetrics),
+		tokenRefreshes: make(map[string]int64),
+	}
+}
+
+func operationLabels(operation Operation) string {
+	return "tag=\"" + escapeLabel(operation.Tag) + "\",operation=\"" + escapeLabel(operation.GetFullName()) + "\""
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(value)
+}
@@ -2,56 +2,517 @@ package sdkgen
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
+	"io/fs"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
 )
 
+// Multipart builds a multipart body, by default the parts are sent as multipart/form-data but through Type it is also
+// possible to send i.e. multipart/mixed or multipart/related, additional media type parameters like the type and start
+// parameter of multipart/related can be set through Parameters. A multipart/form-data body contains like in previous
+// versions first all files and then all fields, every other type contains the parts in the order they were added so
+// that i.e. the root part of multipart/related can be sent first
 type Multipart struct {
-	files       []FilePart
-	fields      []FieldPart
+	Type        string
+	Parameters  map[string]string
+	parts       []multipartPart
+	boundary    string
 	contentType string
+	mutex       sync.Mutex
+	stream      *multipartStream
+}
+
+// multipartStream is the pipe of the last Stream call, the done channel is closed once the writer goroutine returned
+type multipartStream struct {
+	reader *io.PipeReader
+	done   chan struct{}
 }
 
 func (multi *Multipart) AddFile(name string, fileName string, reader io.Reader) {
-	multi.files = append(multi.files, FilePart{name, fileName, reader})
+	open, size := newPartSource(reader)
+	multi.parts = append(multi.parts, FilePart{Name: name, FileName: fileName, Reader: reader, Size: size, SizeKnown: size >= 0, Open: open})
+}
+
+// AddFileSource adds a file which is opened only once the request body is written, the open function is called again
+// in case the request is retried. The size can be -1 if it is unknown
+func (multi *Multipart) AddFileSource(name string, fileName string, size int64, open func() (io.ReadCloser, error)) {
+	multi.parts = append(multi.parts, FilePart{Name: name, FileName: fileName, Size: size, SizeKnown: size >= 0, Open: open})
+}
+
+// AddFilePath adds a file from the local file system, the content type is detected from the file extension or by
+// sniffing the first bytes of the file
+func (multi *Multipart) AddFilePath(name string, filePath string) error {
+	return multi.addFile(name, filepath.Base(filePath), func() (fs.File, error) {
+		return os.Open(filePath)
+	})
+}
+
+// AddFileFS adds a file from the provided file system, the content type is detected like at AddFilePath
+func (multi *Multipart) AddFileFS(name string, fsys fs.FS, filePath string) error {
+	return multi.addFile(name, path.Base(filePath), func() (fs.File, error) {
+		return fsys.Open(filePath)
+	})
+}
+
+func (multi *Multipart) AddFilePart(part FilePart) {
+	if part.Open == nil && part.Reader != nil {
+		open, size := newPartSource(part.Reader)
+		part.Open = open
+		if !part.SizeKnown && size >= 0 {
+			part.Size = size
+			part.SizeKnown = true
+		}
+	}
+
+	multi.parts = append(multi.parts, part)
 }
 
 func (multi *Multipart) AddField(name string, reader io.Reader) {
-	multi.fields = append(multi.fields, FieldPart{name, reader})
+	open, size := newPartSource(reader)
+	multi.parts = append(multi.parts, FieldPart{Name: name, Reader: reader, Size: size, SizeKnown: size >= 0, Open: open})
+}
+
+func (multi *Multipart) AddFieldPart(part FieldPart) {
+	if part.Open == nil && part.Reader != nil {
+		open, size := newPartSource(part.Reader)
+		part.Open = open
+		if !part.SizeKnown && size >= 0 {
+			part.Size = size
+			part.SizeKnown = true
+		}
+	}
+
+	multi.parts = append(multi.parts, part)
+}
+
+// AddJson adds a field which contains the JSON representation of the provided value
+func (multi *Multipart) AddJson(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var reader = bytes.NewReader(raw)
+	open, size := newPartSource(reader)
+	multi.parts = append(multi.parts, FieldPart{Name: name, ContentType: "application/json", Reader: reader, Size: size, SizeKnown: true, Open: open})
+
+	return nil
+}
+
+func (multi *Multipart) GetFiles() []FilePart {
+	var files []FilePart
+	for _, part := range multi.parts {
+		if file, ok := part.(FilePart); ok {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+func (multi *Multipart) GetFields() []FieldPart {
+	var fields []FieldPart
+	for _, part := range multi.parts {
+		if field, ok := part.(FieldPart); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
 }
 
 func (multi *Multipart) GetContentType() string {
+	if multi.contentType == "" {
+		var params = make(map[string]string)
+		for key, value := range multi.Parameters {
+			params[key] = value
+		}
+
+		params["boundary"] = multi.getBoundary()
+
+		multi.contentType = mime.FormatMediaType("multipart/"+multi.getType(), params)
+	}
+
 	return multi.contentType
 }
 
+// GetContentLength returns the size of the complete body or -1 in case the size of a part is unknown, so that the body
+// is sent chunked
+func (multi *Multipart) GetContentLength() int64 {
+	var counter = &countingWriter{}
+	var writer = multipart.NewWriter(counter)
+	_ = writer.SetBoundary(multi.getBoundary())
+
+	var size int64
+	for _, part := range multi.getParts() {
+		if part.getSize() < 0 {
+			return -1
+		}
+
+		_, err := writer.CreatePart(part.createHeader(multi.isFormData()))
+		if err != nil {
+			return -1
+		}
+
+		size += part.getSize()
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return -1
+	}
+
+	return counter.size + size
+}
+
+// Build writes the complete body into a buffer, an error of a part is ignored
+//
+// Deprecated: use BuildWithError which returns the error of a part or Stream
 func (multi *Multipart) Build() *bytes.Buffer {
+	reqBody, _ := multi.BuildWithError()
+
+	return reqBody
+}
+
+// BuildWithError writes the complete body into a buffer and returns the error in case a part could not be written
+func (multi *Multipart) BuildWithError() (*bytes.Buffer, error) {
 	var reqBody = &bytes.Buffer{}
-	writer := multipart.NewWriter(reqBody)
+	err := multi.write(reqBody)
+
+	return reqBody, err
+}
 
-	multi.contentType = writer.FormDataContentType()
+// Stream returns a reader which writes all parts through a pipe while the request is sent, so that files are not
+// buffered in memory. Errors of a part are returned by the Read method of the reader. The writer goroutine is started
+// immediately and only returns once the reader was read completely or closed, because of this the stream should be
+// created after all other steps which could fail. A previous stream of the body is closed and awaited since the
+// parts share the same readers
+func (multi *Multipart) Stream() io.ReadCloser {
+	multi.mutex.Lock()
+	defer multi.mutex.Unlock()
 
-	for _, file := range multi.files {
-		part, _ := writer.CreateFormFile(file.Name, file.FileName)
-		io.Copy(part, file.Reader)
+	if multi.stream != nil {
+		multi.stream.reader.CloseWithError(errors.New("the multipart body was reopened"))
+		<-multi.stream.done
 	}
 
-	for _, field := range multi.fields {
-		part, _ := writer.CreateFormField(field.Name)
-		io.Copy(part, field.Reader)
+	reader, writer := io.Pipe()
+	var done = make(chan struct{})
+	multi.getBoundary()
+	multi.stream = &multipartStream{reader: reader, done: done}
+
+	go func() {
+		defer close(done)
+		writer.CloseWithError(multi.write(writer))
+	}()
+
+	return reader
+}
+
+// GetBody returns a function which produces a new stream of the body, it returns nil if a part can not be reopened
+// which means that a request with this body can not be retried. Like at Stream the writer of the previous stream is
+// stopped before the parts are reopened
+func (multi *Multipart) GetBody() func() (io.ReadCloser, error) {
+	for _, part := range multi.parts {
+		if !part.canReopen() {
+			return nil
+		}
 	}
 
-	writer.Close()
+	return func() (io.ReadCloser, error) {
+		return multi.Stream(), nil
+	}
+}
 
-	return reqBody
+func (multi *Multipart) write(target io.Writer) error {
+	writer := multipart.NewWriter(target)
+
+	err := writer.SetBoundary(multi.getBoundary())
+	if err != nil {
+		return err
+	}
+
+	for _, part := range multi.getParts() {
+		partWriter, err := writer.CreatePart(part.createHeader(multi.isFormData()))
+		if err != nil {
+			return err
+		}
+
+		err = part.copyTo(partWriter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
 }
 
+func (multi *Multipart) addFile(name string, fileName string, open func() (fs.File, error)) error {
+	file, err := open()
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	var contentType = mime.TypeByExtension(path.Ext(fileName))
+	if contentType == "" {
+		var buffer = make([]byte, 512)
+		n, err := io.ReadFull(file, buffer)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		contentType = http.DetectContentType(buffer[:n])
+	}
+
+	multi.parts = append(multi.parts, FilePart{
+		Name:        name,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        info.Size(),
+		SizeKnown:   true,
+		Open: func() (io.ReadCloser, error) {
+			return open()
+		},
+	})
+
+	return nil
+}
+
+// getParts returns the parts in the order in which they are written
+func (multi *Multipart) getParts() []multipartPart {
+	if !multi.isFormData() {
+		return multi.parts
+	}
+
+	var parts = make([]multipartPart, 0, len(multi.parts))
+	for _, file := range multi.GetFiles() {
+		parts = append(parts, file)
+	}
+
+	for _, field := range multi.GetFields() {
+		parts = append(parts, field)
+	}
+
+	return parts
+}
+
+func (multi *Multipart) getType() string {
+	if multi.Type == "" {
+		return "form-data"
+	}
+
+	return multi.Type
+}
+
+func (multi *Multipart) isFormData() bool {
+	return multi.getType() == "form-data"
+}
+
+func (multi *Multipart) getBoundary() string {
+	if multi.boundary == "" {
+		multi.boundary = multipart.NewWriter(io.Discard).Boundary()
+	}
+
+	return multi.boundary
+}
+
+func NewMultipart(subtype string, parameters map[string]string) *Multipart {
+	return &Multipart{
+		Type:       subtype,
+		Parameters: parameters,
+	}
+}
+
+type multipartPart interface {
+	createHeader(formData bool) textproto.MIMEHeader
+	getSize() int64
+	canReopen() bool
+	copyTo(writer io.Writer) error
+}
+
+// FilePart describes a file of a multipart body, the Size is only used to calculate the Content-Length of the body if
+// SizeKnown is true, otherwise the size is detected from the Reader if possible
 type FilePart struct {
-	Name     string
-	FileName string
-	Reader   io.Reader
+	Name        string
+	FileName    string
+	ContentType string
+	Header      textproto.MIMEHeader
+	Reader      io.Reader
+	Size        int64
+	SizeKnown   bool
+	Open        func() (io.ReadCloser, error)
 }
 
+func (part FilePart) createHeader(formData bool) textproto.MIMEHeader {
+	var header = make(textproto.MIMEHeader)
+	if formData {
+		header.Set("Content-Disposition", "form-data; name=\""+escapeQuotes(part.Name)+"\"; filename=\""+escapeQuotes(part.FileName)+"\"")
+	} else if part.FileName != "" {
+		header.Set("Content-Disposition", "attachment; filename=\""+escapeQuotes(part.FileName)+"\"")
+	}
+
+	if part.ContentType != "" {
+		header.Set("Content-Type", part.ContentType)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+
+	return mergeHeader(header, part.Header)
+}
+
+func (part FilePart) getSize() int64 {
+	if !part.SizeKnown || part.Size < 0 {
+		return -1
+	}
+
+	return part.Size
+}
+
+func (part FilePart) canReopen() bool {
+	return part.Open != nil
+}
+
+func (part FilePart) copyTo(writer io.Writer) error {
+	return copyPart(writer, part.Reader, part.Open)
+}
+
+// FieldPart describes a field of a multipart body, the Size is handled like at the FilePart
 type FieldPart struct {
-	Name   string
-	Reader io.Reader
+	Name        string
+	ContentType string
+	Header      textproto.MIMEHeader
+	Reader      io.Reader
+	Size        int64
+	SizeKnown   bool
+	Open        func() (io.ReadCloser, error)
+}
+
+func (part FieldPart) createHeader(formData bool) textproto.MIMEHeader {
+	var header = make(textproto.MIMEHeader)
+	if formData {
+		header.Set("Content-Disposition", "form-data; name=\""+escapeQuotes(part.Name)+"\"")
+	}
+
+	if part.ContentType != "" {
+		header.Set("Content-Type", part.ContentType)
+	}
+
+	return mergeHeader(header, part.Header)
+}
+
+func (part FieldPart) getSize() int64 {
+	if !part.SizeKnown || part.Size < 0 {
+		return -1
+	}
+
+	return part.Size
+}
+
+func (part FieldPart) canReopen() bool {
+	return part.Open != nil
+}
+
+func (part FieldPart) copyTo(writer io.Writer) error {
+	return copyPart(writer, part.Reader, part.Open)
+}
+
+// newPartSource detects the size of a reader and whether it can be read again, this is the case for all readers which
+// support seeking since we can rewind them to the current offset
+func newPartSource(reader io.Reader) (func() (io.ReadCloser, error), int64) {
+	var size int64 = -1
+	switch source := reader.(type) {
+	case *bytes.Reader:
+		size = int64(source.Len())
+	case *strings.Reader:
+		size = int64(source.Len())
+	case *bytes.Buffer:
+		return nil, int64(source.Len())
+	case *os.File:
+		info, err := source.Stat()
+		if err == nil && info.Mode().IsRegular() {
+			offset, err := source.Seek(0, io.SeekCurrent)
+			if err == nil {
+				size = info.Size() - offset
+			}
+		}
+	}
+
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return nil, size
+	}
+
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, size
+	}
+
+	return func() (io.ReadCloser, error) {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(reader), nil
+	}, size
+}
+
+func copyPart(part io.Writer, reader io.Reader, open func() (io.ReadCloser, error)) error {
+	if open == nil {
+		if reader == nil {
+			return nil
+		}
+
+		_, err := io.Copy(part, reader)
+		return err
+	}
+
+	source, err := open()
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	_, err = io.Copy(part, source)
+
+	return err
+}
+
+func mergeHeader(header textproto.MIMEHeader, additional textproto.MIMEHeader) textproto.MIMEHeader {
+	for key, values := range additional {
+		header[textproto.CanonicalMIMEHeaderKey(key)] = values
+	}
+
+	return header
+}
+
+func escapeQuotes(value string) string {
+	return strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace(value)
+}
+
+type countingWriter struct {
+	size int64
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	writer.size += int64(len(p))
+	return len(p), nil
 }
@@ -0,0 +1,143 @@
+package sdkgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// MultipartReader iterates over the parts of a multipart response, the body of a part is streamed and must be read
+// before the next part is requested
+type MultipartReader struct {
+	reader *multipart.Reader
+	body   io.Closer
+}
+
+func (reader *MultipartReader) NextPart() (*ResponsePart, error) {
+	part, err := reader.reader.NextPart()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponsePart{
+		Header:      part.Header,
+		ContentType: part.Header.Get("Content-Type"),
+		Name:        part.FormName(),
+		FileName:    part.FileName(),
+		Body:        part,
+	}, nil
+}
+
+func (reader *MultipartReader) Close() error {
+	if reader.body == nil {
+		return nil
+	}
+
+	return reader.body.Close()
+}
+
+func NewMultipartReader(contentType string, body io.Reader) (*MultipartReader, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.New("could not parse multipart content type")
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, errors.New("received no multipart content type: " + mediaType)
+	}
+
+	var boundary = params["boundary"]
+	if boundary == "" {
+		return nil, errors.New("multipart content type contains no boundary")
+	}
+
+	var closer io.Closer
+	if body, ok := body.(io.Closer); ok {
+		closer = body
+	}
+
+	return &MultipartReader{
+		reader: multipart.NewReader(body, boundary),
+		body:   closer,
+	}, nil
+}
+
+func NewMultipartResponseReader(resp *http.Response) (*MultipartReader, error) {
+	return NewMultipartReader(resp.Header.Get("Content-Type"), resp.Body)
+}
+
+type ResponsePart struct {
+	Header      textproto.MIMEHeader
+	ContentType string
+	Name        string
+	FileName    string
+	Body        io.Reader
+}
+
+func (part *ResponsePart) IsJson() bool {
+	mediaType, _, _ := mime.ParseMediaType(part.ContentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Decode decodes the JSON body of the part into the provided target
+func (part *ResponsePart) Decode(target interface{}) error {
+	if part.ContentType != "" && !part.IsJson() {
+		return errors.New("could not decode part with content type " + part.ContentType)
+	}
+
+	return json.NewDecoder(part.Body).Decode(target)
+}
+
+func DecodePart[T any](part *ResponsePart) (T, error) {
+	var data T
+	err := part.Decode(&data)
+
+	return data, err
+}
+
+// ParseMultipart reads all parts of a multipart body into a Multipart, the parts are buffered in memory. Parts with a
+// file name are added as file all other parts are added as field
+func ParseMultipart(contentType string, body io.Reader) (*Multipart, error) {
+	reader, err := NewMultipartReader(contentType, body)
+	if err != nil {
+		return &Multipart{}, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var result = &Multipart{Type: strings.TrimPrefix(mediaType, "multipart/")}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return result, err
+		}
+
+		raw, err := io.ReadAll(part.Body)
+		if err != nil {
+			return result, err
+		}
+
+		var header = make(textproto.MIMEHeader)
+		for key, values := range part.Header {
+			if key != "Content-Disposition" && key != "Content-Type" {
+				header[key] = values
+			}
+		}
+
+		if part.FileName != "" {
+			result.AddFilePart(FilePart{Name: part.Name, FileName: part.FileName, ContentType: part.ContentType, Header: header, Reader: bytes.NewReader(raw)})
+		} else {
+			result.AddFieldPart(FieldPart{Name: part.Name, ContentType: part.ContentType, Header: header, Reader: bytes.NewReader(raw)})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package sdkgen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const NdjsonContentType = "application/x-ndjson"
+
+// Iterator is implemented by all runtime helpers which yield typed items one at a time
+type Iterator[T any] interface {
+	Next() bool
+	Item() T
+	Err() error
+}
+
+// NdjsonLineError describes an error at a specific line of a NDJSON stream, the line number starts at 1
+type NdjsonLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *NdjsonLineError) Error() string {
+	return fmt.Sprintf("could not decode line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *NdjsonLineError) Unwrap() error {
+	return e.Err
+}
+
+// NdjsonIterator decodes a NDJSON stream line by line so that only a single item is in memory. By default the
+// iterator stops at the first invalid line, if SkipInvalid is enabled invalid lines are collected and can be obtained
+// through Errors
+type NdjsonIterator[T any] struct {
+	SkipInvalid bool
+	reader      *bufio.Reader
+	closer      io.Closer
+	line        int
+	item        T
+	err         error
+	errors      []error
+	done        bool
+}
+
+func (iterator *NdjsonIterator[T]) Next() bool {
+	for !iterator.done {
+		raw, err := iterator.reader.ReadBytes('\n')
+		if err == io.EOF {
+			iterator.done = true
+		} else if err != nil {
+			iterator.err = err
+			iterator.done = true
+			return false
+		}
+
+		var line = bytes.TrimSpace(raw)
+		if len(raw) > 0 {
+			iterator.line++
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		var item T
+		err = json.Unmarshal(line, &item)
+		if err != nil {
+			var lineError = &NdjsonLineError{Line: iterator.line, Err: err}
+			if iterator.SkipInvalid {
+				iterator.errors = append(iterator.errors, lineError)
+				continue
+			}
+
+			iterator.err = lineError
+			iterator.done = true
+			return false
+		}
+
+		iterator.item = item
+		return true
+	}
+
+	return false
+}
+
+func (iterator *NdjsonIterator[T]) Item() T {
+	return iterator.item
+}
+
+func (iterator *NdjsonIterator[T]) Err() error {
+	return iterator.err
+}
+
+func (iterator *NdjsonIterator[T]) Errors() []error {
+	return iterator.errors
+}
+
+// Close stops the iteration and closes the underlying body, this can be used to terminate early
+func (iterator *NdjsonIterator[T]) Close() error {
+	iterator.done = true
+	if iterator.closer == nil {
+		return nil
+	}
+
+	return iterator.closer.Close()
+}
+
+func NewNdjsonIterator[T any](body io.Reader) *NdjsonIterator[T] {
+	var closer io.Closer
+	if body, ok := body.(io.Closer); ok {
+		closer = body
+	}
+
+	return &NdjsonIterator[T]{
+		reader: bufio.NewReader(body),
+		closer: closer,
+	}
+}
+
+// NewNdjsonBody returns a request body which encodes every item of the iterator as a single line while the request
+// is sent
+func NewNdjsonBody[T any](iterator Iterator[T]) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		var encoder = json.NewEncoder(writer)
+		for iterator.Next() {
+			err := encoder.Encode(iterator.Item())
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+
+		writer.CloseWithError(iterator.Err())
+	}()
+
+	return reader
+}
+
+// NewNdjsonChannelBody returns a request body which encodes every item received from the channel until the channel
+// is closed
+func NewNdjsonChannelBody[T any](channel <-chan T) io.ReadCloser {
+	return NewNdjsonBody[T](&channelIterator[T]{channel: channel})
+}
+
+type channelIterator[T any] struct {
+	channel <-chan T
+	item    T
+}
+
+func (iterator *channelIterator[T]) Next() bool {
+	item, ok := <-iterator.channel
+	iterator.item = item
+
+	return ok
+}
+
+func (iterator *channelIterator[T]) Item() T {
+	return iterator.item
+}
+
+func (iterator *channelIterator[T]) Err() error {
+	return nil
+}
@@ -0,0 +1,48 @@
+package sdkgen
+
+import "context"
+
+// Operation describes the generated method which has created a request. Every generated method attaches the
+// operation to the request context so that middlewares can key on the operation instead of the raw url
+type Operation struct {
+	Id          string
+	Tag         string
+	Name        string
+	Method      string
+	Path        string
+	Security    []string
+	StatusCodes []int
+}
+
+func (operation Operation) GetFullName() string {
+	if operation.Tag == "" {
+		return operation.Name
+	}
+
+	return operation.Tag + "." + operation.Name
+}
+
+func (operation Operation) IsExpectedStatusCode(statusCode int) bool {
+	if statusCode >= 200 && statusCode < 300 {
+		return true
+	}
+
+	for _, expected := range operation.StatusCodes {
+		if expected == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+type operationKey struct{}
+
+func WithOperation(ctx context.Context, operation Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+func GetOperation(ctx context.Context) (Operation, bool) {
+	operation, ok := ctx.Value(operationKey{}).(Operation)
+	return operation, ok
+}
@@ -0,0 +1,77 @@
+package sdkgen
+
+import (
+	"encoding/json"
+)
+
+// Nullable contains a value which can be null. To distinguish between an absent value, an explicit null and a value
+// i.e. for a PATCH request a field is declared as pointer to a Nullable with the omitempty option, a nil pointer is
+// then omitted, Null returns an explicit null and Some a value. Since encoding/json sets such a pointer to nil in case
+// the JSON contains null, a patch model must be decoded through UnmarshalPatch to keep an explicit null
+type Nullable[T any] struct {
+	Value T
+	Valid bool
+}
+
+func (nullable Nullable[T]) Get() (T, bool) {
+	return nullable.Value, nullable.Valid
+}
+
+func (nullable Nullable[T]) IsNull() bool {
+	return !nullable.Valid
+}
+
+// GetOrDefault returns the value or the provided default value in case the value is null
+func (nullable Nullable[T]) GetOrDefault(defaultValue T) T {
+	if !nullable.Valid {
+		return defaultValue
+	}
+
+	return nullable.Value
+}
+
+func (nullable Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !nullable.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(nullable.Value)
+}
+
+func (nullable *Nullable[T]) UnmarshalJSON(data []byte) error {
+	var empty T
+	if string(data) == "null" {
+		nullable.Value = empty
+		nullable.Valid = false
+		return nil
+	}
+
+	var value T
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	nullable.Value = value
+	nullable.Valid = true
+
+	return nil
+}
+
+func (nullable Nullable[T]) getOptionalValue() (interface{}, bool, bool) {
+	return nullable.Value, true, !nullable.Valid
+}
+
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{Value: value, Valid: true}
+}
+
+// Some returns a pointer to a Nullable which contains the provided value
+func Some[T any](value T) *Nullable[T] {
+	return &Nullable[T]{Value: value, Valid: true}
+}
+
+// Null returns a pointer to a Nullable which contains an explicit null
+func Null[T any]() *Nullable[T] {
+	return &Nullable[T]{}
+}
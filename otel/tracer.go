@@ -0,0 +1,81 @@
+package sdkgenotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apioo/sdkgen-go/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer adapts an OpenTelemetry tracer to the sdkgen.TracerInterface
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+func (tracer *Tracer) Start(ctx context.Context, name string) (context.Context, sdkgen.SpanInterface) {
+	ctx, span := tracer.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, &Span{span: span}
+}
+
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return &Tracer{
+		tracer: provider.Tracer("github.com/apioo/sdkgen-go"),
+	}
+}
+
+type Span struct {
+	span trace.Span
+}
+
+func (span *Span) GetSpanContext() sdkgen.SpanContext {
+	var spanContext = span.span.SpanContext()
+	if !spanContext.IsValid() {
+		return sdkgen.SpanContext{}
+	}
+
+	return sdkgen.SpanContext{
+		TraceId:    spanContext.TraceID().String(),
+		SpanId:     spanContext.SpanID().String(),
+		Sampled:    spanContext.IsSampled(),
+		TraceState: spanContext.TraceState().String(),
+	}
+}
+
+func (span *Span) SetAttribute(key string, value interface{}) {
+	switch data := value.(type) {
+	case string:
+		span.span.SetAttributes(attribute.String(key, data))
+	case int:
+		span.span.SetAttributes(attribute.Int(key, data))
+	case int64:
+		span.span.SetAttributes(attribute.Int64(key, data))
+	case float64:
+		span.span.SetAttributes(attribute.Float64(key, data))
+	case bool:
+		span.span.SetAttributes(attribute.Bool(key, data))
+	default:
+		span.span.SetAttributes(attribute.String(key, fmt.Sprint(data)))
+	}
+
+	if key == "error.type" {
+		span.span.SetStatus(codes.Error, fmt.Sprint(value))
+	}
+}
+
+func (span *Span) SetError(err error) {
+	span.span.RecordError(err)
+	span.span.SetStatus(codes.Error, err.Error())
+}
+
+func (span *Span) End() {
+	span.span.End()
+}
@@ -0,0 +1,243 @@
+package sdkgen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Paginator iterates over all items of a paginated collection and fetches the next page once all items of the current
+// page are consumed. If Prefetch is enabled the next page is already requested in the background while the items of
+// the current page are consumed, through MaxItems it is possible to stop after a specific number of items
+type Paginator[T any] struct {
+	Prefetch bool
+	MaxItems int
+	ctx      context.Context
+	fetch    func(ctx context.Context) ([]T, bool, error)
+	items    []T
+	index    int
+	item     T
+	count    int
+	err      error
+	done     bool
+	pending  chan paginatorPage[T]
+}
+
+func (paginator *Paginator[T]) Next() bool {
+	if paginator.err != nil {
+		return false
+	}
+
+	if paginator.MaxItems > 0 && paginator.count >= paginator.MaxItems {
+		return false
+	}
+
+	for paginator.index >= len(paginator.items) {
+		if paginator.done {
+			return false
+		}
+
+		err := paginator.ctx.Err()
+		if err != nil {
+			paginator.err = err
+			return false
+		}
+
+		page := paginator.nextPage()
+		if page.err != nil {
+			paginator.err = page.err
+			return false
+		}
+
+		paginator.items = page.items
+		paginator.index = 0
+		paginator.done = !page.more
+
+		// there is no need to prefetch the next page in case the current page already contains the last item
+		var reachesMaxItems = paginator.MaxItems > 0 && paginator.count+len(page.items) >= paginator.MaxItems
+		if paginator.Prefetch && page.more && !reachesMaxItems {
+			paginator.startPrefetch()
+		}
+	}
+
+	paginator.item = paginator.items[paginator.index]
+	paginator.index++
+	paginator.count++
+
+	return true
+}
+
+func (paginator *Paginator[T]) Item() T {
+	return paginator.item
+}
+
+func (paginator *Paginator[T]) Err() error {
+	return paginator.err
+}
+
+// All consumes the complete iterator and returns all items
+func (paginator *Paginator[T]) All() ([]T, error) {
+	var items []T
+	for paginator.Next() {
+		items = append(items, paginator.Item())
+	}
+
+	return items, paginator.Err()
+}
+
+func (paginator *Paginator[T]) nextPage() paginatorPage[T] {
+	if paginator.pending == nil {
+		items, more, err := paginator.fetch(paginator.ctx)
+		return paginatorPage[T]{items: items, more: more, err: err}
+	}
+
+	var pending = paginator.pending
+	paginator.pending = nil
+
+	select {
+	case page := <-pending:
+		return page
+	case <-paginator.ctx.Done():
+		return paginatorPage[T]{err: paginator.ctx.Err()}
+	}
+}
+
+func (paginator *Paginator[T]) startPrefetch() {
+	// the channel is buffered so that the goroutine does not block in case the iteration is not continued
+	var pending = make(chan paginatorPage[T], 1)
+	paginator.pending = pending
+
+	go func() {
+		items, more, err := paginator.fetch(paginator.ctx)
+		pending <- paginatorPage[T]{items: items, more: more, err: err}
+	}()
+}
+
+func NewPaginator[T any](ctx context.Context, fetch func(ctx context.Context) ([]T, bool, error)) *Paginator[T] {
+	return &Paginator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+	}
+}
+
+// NewOffsetPaginator pages through a collection by startIndex and count, the iteration stops once a page contains
+// less items than requested
+func NewOffsetPaginator[T any](ctx context.Context, count int, fetch func(ctx context.Context, startIndex int, count int) ([]T, error)) *Paginator[T] {
+	var startIndex = 0
+
+	return NewPaginator[T](ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, err := fetch(ctx, startIndex, count)
+		if err != nil {
+			return nil, false, err
+		}
+
+		startIndex += len(items)
+
+		return items, len(items) > 0 && len(items) >= count, nil
+	})
+}
+
+// NewPagePaginator pages through a collection by page number and page size, the iteration starts at the first page
+// and stops once a page contains less items than the page size
+func NewPagePaginator[T any](ctx context.Context, firstPage int, pageSize int, fetch func(ctx context.Context, page int, pageSize int) ([]T, error)) *Paginator[T] {
+	var page = firstPage
+
+	return NewPaginator[T](ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, err := fetch(ctx, page, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page++
+
+		return items, len(items) > 0 && len(items) >= pageSize, nil
+	})
+}
+
+// NewCursorPaginator pages through a collection by a cursor or next token, the first page is requested with an empty
+// cursor and the iteration stops once the server returns no next cursor
+func NewCursorPaginator[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) ([]T, string, error)) *Paginator[T] {
+	var cursor = ""
+
+	return NewPaginator[T](ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if next == cursor {
+			return items, false, nil
+		}
+
+		cursor = next
+
+		return items, next != "", nil
+	})
+}
+
+// NewLinkPaginator requests the url through the http client and follows the Link header with the relation next until
+// the server returns no next link, the decode function extracts the items from a response. Like at the Follow method a
+// next link to a different origin is only followed if the context was created through WithCrossOrigin
+func NewLinkPaginator[T any](ctx context.Context, httpClient *http.Client, rawUrl string, decode func(resp *http.Response) ([]T, error)) *Paginator[T] {
+	var next = rawUrl
+	var nextErr error
+	origin, originErr := url.Parse(rawUrl)
+
+	return NewPaginator[T](ctx, func(ctx context.Context) ([]T, bool, error) {
+		if originErr != nil {
+			return nil, false, originErr
+		} else if nextErr != nil {
+			return nil, false, nextErr
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", next, nil)
+		if err != nil {
+			return nil, false, err
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, false, errors.New("the server returned an unexpected status code: " + strconv.Itoa(resp.StatusCode))
+		}
+
+		items, err := decode(resp)
+		if err != nil {
+			return nil, false, err
+		}
+
+		link, ok := GetLinks(resp.Header).Get("next")
+		if !ok {
+			return items, false, nil
+		}
+
+		target, err := req.URL.Parse(link.Href)
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = checkOrigin(ctx, origin, target.String())
+		if err != nil {
+			// the items of this page are still returned, the error is reported once the next page is requested
+			nextErr = err
+			return items, true, nil
+		}
+
+		next = target.String()
+
+		return items, true, nil
+	})
+}
+
+type paginatorPage[T any] struct {
+	items []T
+	more  bool
+	err   error
+}
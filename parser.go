@@ -1,59 +1,284 @@
 package sdkgen
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
+	"io"
+	"mime"
+	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type Parser struct {
 	BaseUrl string
+	Codec   CodecInterface
+	codecs  map[string]CodecInterface
 }
 
 func (parser *Parser) Url(path string, parameters map[string]interface{}) string {
 	return parser.BaseUrl + "/" + parser.SubstituteParameters(path, parameters)
 }
 
+// UrlWithError works like Url but returns an error in case a parameter can not be encoded or a segment contains an
+// invalid template, the generated client uses this method so that such an error is not silently dropped
+func (parser *Parser) UrlWithError(path string, parameters map[string]interface{}) (string, error) {
+	substituted, err := parser.SubstituteParametersWithError(path, parameters)
+	if err != nil {
+		return "", err
+	}
+
+	return parser.BaseUrl + "/" + substituted, nil
+}
+
+// UrlTemplate expands the RFC 6570 URI template i.e. /files{/path*}{?q} and appends it to the base url
+func (parser *Parser) UrlTemplate(template string, parameters map[string]interface{}) (string, error) {
+	expanded, err := ExpandUriTemplate(template, parameters)
+	if err != nil {
+		return "", err
+	}
+
+	return parser.BaseUrl + "/" + strings.TrimPrefix(expanded, "/"), nil
+}
+
 func (parser *Parser) Parse(data string, model *interface{}) error {
-	err := json.Unmarshal([]byte(data), &model)
+	err := parser.GetCodec().Decode(strings.NewReader(data), &model)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// GetCodec returns the codec which is used to encode payloads and decode responses, by default the JsonCodec is used
+func (parser *Parser) GetCodec() CodecInterface {
+	if parser.Codec == nil {
+		return NewJsonCodec()
+	}
+
+	return parser.Codec
+}
+
+func (parser *Parser) Encode(value interface{}) ([]byte, error) {
+	return parser.GetCodec().Encode(value)
+}
+
+func (parser *Parser) Decode(body io.Reader, target interface{}) error {
+	return parser.GetCodec().Decode(body, target)
+}
+
+// RegisterCodec registers a codec for the content type of the codec, the codecs must be registered before the first
+// request is sent
+func (parser *Parser) RegisterCodec(codec CodecInterface) {
+	if parser.codecs == nil {
+		parser.codecs = make(map[string]CodecInterface)
+	}
+
+	parser.codecs[codec.ContentType()] = codec
+}
+
+// GetCodecFor returns the codec for the provided content type. If no codec was registered every XML media type i.e.
+// application/soap+xml is handled by the XmlCodec, a form by the FormCodec and all other media types by the default
+// codec
+func (parser *Parser) GetCodecFor(contentType string) CodecInterface {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return parser.GetCodec()
+	}
+
+	if codec, ok := parser.codecs[mediaType]; ok {
+		return codec
+	}
+
+	var codec = parser.GetCodec()
+	if codec.ContentType() == mediaType {
+		return codec
+	} else if strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml") {
+		return NewXmlCodec()
+	} else if mediaType == FormContentType {
+		return NewFormCodec()
+	}
+
+	return codec
+}
+
+// DecodeResponse decodes the body with the codec which matches the Content-Type of the response
+func (parser *Parser) DecodeResponse(resp *http.Response, target interface{}) error {
+	return parser.GetCodecFor(resp.Header.Get("Content-Type")).Decode(resp.Body, target)
+}
+
+// Accept returns the value of an Accept header which prefers the provided content types in the given order
+func (parser *Parser) Accept(contentTypes ...string) string {
+	var result = make([]string, 0, len(contentTypes))
+	for i, contentType := range contentTypes {
+		if i == 0 {
+			result = append(result, contentType)
+			continue
+		}
+
+		var quality = 10 - i
+		if quality < 1 {
+			quality = 1
+		}
+
+		result = append(result, contentType+";q=0."+strconv.Itoa(quality))
+	}
+
+	return strings.Join(result, ", ")
+}
+
 func (parser *Parser) Query(parameters map[string]interface{}) url.Values {
 	return parser.QueryWithStruct(parameters, []string{})
 }
 
+// QueryWithStruct serializes the parameters with the form style, a parameter which can not be encoded is skipped, use
+// QueryWithStyle to receive such an error
 func (parser *Parser) QueryWithStruct(parameters map[string]interface{}, structNames []string) url.Values {
+	result, _ := parser.QueryWithStyle(parameters, structNames, nil)
+
+	return result
+}
+
+// QueryWithStyle serializes the parameters according to the OpenAPI query styles, the style of a parameter is obtained
+// from the styles map and by default the form style with explode is used. Parameters which are contained in the
+// struct names are serialized as object. An error is returned in case a value can not be encoded, the returned values
+// contain all other parameters
+func (parser *Parser) QueryWithStyle(parameters map[string]interface{}, structNames []string, styles map[string]QueryStyle) (url.Values, error) {
+	var lastError error
 	var result = url.Values{}
 	for name, value := range parameters {
 		if value == "" {
 			continue
 		}
 
-		if parser.Contains(structNames, name) {
-			var properties map[string]interface{}
-			data, _ := json.Marshal(value)
-			json.Unmarshal(data, &properties)
+		style, ok := styles[name]
+		if !ok {
+			style = QueryStyle{Style: QueryStyleForm, Explode: true}
+		}
+
+		err := addQueryValue(result, name, parser.normalizeQueryValue(value, parser.Contains(structNames, name)), style)
+		if err != nil {
+			lastError = err
+		}
+	}
 
-			var values = parser.QueryWithStruct(properties, []string{})
-			for nestedName, nestedValue := range values {
-				for _, v := range nestedValue {
-					result.Add(nestedName, v)
+	return result, lastError
+}
+
+func addQueryValue(result url.Values, name string, value interface{}, style QueryStyle) error {
+	switch value := value.(type) {
+	case []interface{}:
+		var values = make([]string, 0, len(value))
+		for _, item := range value {
+			encoded, err := EncodeValue(item)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, encoded)
+		}
+
+		switch style.Style {
+		case QueryStyleDeepObject, QueryStyleBracket:
+			for _, item := range values {
+				result.Add(name+"[]", item)
+			}
+		case QueryStyleSpaceDelimited, QueryStylePipeDelimited, QueryStyleForm, "":
+			if style.Explode {
+				for _, item := range values {
+					result.Add(name, item)
 				}
+			} else {
+				result.Add(name, strings.Join(values, style.getDelimiter()))
 			}
-		} else {
-			result.Add(name, ToString(value))
 		}
+	case map[string]interface{}:
+		var keys = make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		switch style.Style {
+		case QueryStyleDeepObject, QueryStyleBracket:
+			for _, key := range keys {
+				err := addQueryValue(result, name+"["+key+"]", value[key], style)
+				if err != nil {
+					return err
+				}
+			}
+		case QueryStyleSpaceDelimited, QueryStylePipeDelimited, QueryStyleForm, "":
+			if style.Explode {
+				for _, key := range keys {
+					err := addQueryValue(result, key, value[key], style)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
+				var values = make([]string, 0, len(keys)*2)
+				for _, key := range keys {
+					encoded, err := EncodeValue(value[key])
+					if err != nil {
+						return err
+					}
+
+					values = append(values, key, encoded)
+				}
+
+				result.Add(name, strings.Join(values, style.getDelimiter()))
+			}
+		}
+	default:
+		encoded, err := EncodeValue(value)
+		if err != nil {
+			return err
+		}
+
+		result.Add(name, encoded)
 	}
 
-	return result
+	return nil
+}
+
+// normalizeQueryValue converts slices into a generic slice and objects into a generic map, objects are converted through
+// their JSON representation so that the JSON property names are used
+func (parser *Parser) normalizeQueryValue(value interface{}, isStruct bool) interface{} {
+	if value == nil || (!isStruct && isScalarValue(value)) {
+		return value
+	}
+
+	var kind = reflect.TypeOf(value).Kind()
+	if isStruct || kind == reflect.Map {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return map[string]interface{}{}
+		}
+
+		var decoder = json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		var properties map[string]interface{}
+		err = decoder.Decode(&properties)
+		if err != nil || properties == nil {
+			return map[string]interface{}{}
+		}
+
+		return properties
+	} else if kind == reflect.Slice || kind == reflect.Array {
+		var reflected = reflect.ValueOf(value)
+		var items = make([]interface{}, 0, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			items = append(items, reflected.Index(i).Interface())
+		}
+
+		return items
+	}
+
+	return value
 }
 
 func (parser *Parser) Contains(haystack []string, needle string) bool {
@@ -67,6 +292,15 @@ func (parser *Parser) Contains(haystack []string, needle string) bool {
 }
 
 func (parser *Parser) SubstituteParameters(path string, parameters map[string]interface{}) string {
+	result, _ := parser.SubstituteParametersWithError(path, parameters)
+
+	return result
+}
+
+// SubstituteParametersWithError replaces the parameters of the path, a segment which can not be substituted is kept as
+// it is and the last error is returned. A {name} segment whose parameter is missing is also kept as it is
+func (parser *Parser) SubstituteParametersWithError(path string, parameters map[string]interface{}) (string, error) {
+	var lastError error
 	var parts = strings.Split(path, "/")
 	var result []string
 
@@ -85,58 +319,42 @@ func (parser *Parser) SubstituteParameters(path string, parameters map[string]in
 			} else {
 				name = part[1:]
 			}
-		} else if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+		} else if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") && isValidVarname(part[1:len(part)-1]) {
 			name = part[1 : len(part)-1]
+		} else if strings.Contains(part, "{") {
+			// a segment which contains an expression is expanded as URI template so that i.e. {id}.json is supported
+			expanded, err := ExpandUriTemplate(part, parameters)
+			if err != nil {
+				lastError = err
+			} else {
+				part = expanded
+			}
+
+			result = append(result, part)
+			continue
 		}
 
 		value, ok := parameters[name]
 		if ok {
-			part = ToString(value)
+			encoded, err := EncodeValue(value)
+			if err != nil {
+				lastError = err
+			} else {
+				part = url.PathEscape(encoded)
+			}
 		}
 
 		result = append(result, part)
 	}
 
-	return strings.Join(result, "/")
+	return strings.Join(result, "/"), lastError
 }
 
+// ToString returns the string representation of a parameter value, an unsupported value results in an empty string,
+// use EncodeValue to obtain an error in this case
 func ToString(value interface{}) string {
-	if value == nil {
-		return ""
-	}
-
-	if reflect.TypeOf(value).Name() == "string" {
-		return value.(string)
-	} else if reflect.TypeOf(value).Name() == "float32" || reflect.TypeOf(value).Name() == "float64" {
-		return fmt.Sprintf("%g", value)
-	} else if reflect.TypeOf(value).Name() == "int" {
-		return strconv.FormatInt(int64(value.(int)), 10)
-	} else if reflect.TypeOf(value).Name() == "int8" {
-		return strconv.FormatInt(int64(value.(int8)), 10)
-	} else if reflect.TypeOf(value).Name() == "int16" {
-		return strconv.FormatInt(int64(value.(int16)), 10)
-	} else if reflect.TypeOf(value).Name() == "int32" {
-		return strconv.FormatInt(int64(value.(int32)), 10)
-	} else if reflect.TypeOf(value).Name() == "int64" {
-		return strconv.FormatInt(value.(int64), 10)
-	} else if reflect.TypeOf(value).Name() == "bool" {
-		if value.(bool) {
-			return "1"
-		} else {
-			return "0"
-		}
-	} else if reflect.TypeOf(value).Name() == "Time" {
-		var dateTime = value.(time.Time)
-		if dateTime.Year() == 1970 && dateTime.Month() == 1 && dateTime.Day() == 1 {
-			return dateTime.Format(time.TimeOnly)
-		} else if dateTime.Hour() == 0 && dateTime.Minute() == 0 && dateTime.Second() == 0 {
-			return dateTime.Format(time.DateOnly)
-		} else {
-			return dateTime.Format(time.RFC3339)
-		}
-	} else {
-		return ""
-	}
+	result, _ := EncodeValue(value)
+	return result
 }
 
 func NewParser(baseUrl string) *Parser {
@@ -0,0 +1,148 @@
+package sdkgen
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	ProgressUpload   = "upload"
+	ProgressDownload = "download"
+)
+
+// Progress describes the state of a transfer, Total is -1 in case the size is unknown and Rate contains the average
+// transfer rate in bytes per second
+type Progress struct {
+	Direction string
+	Bytes     int64
+	Total     int64
+	Rate      float64
+	Done      bool
+}
+
+type ProgressFunc func(progress Progress)
+
+// ProgressChannel returns a progress function which sends every progress to the provided channel, in case the channel
+// is full the progress is dropped so that a slow or missing consumer never blocks the transfer. Use a buffered channel
+// to receive the final progress reliably
+func ProgressChannel(channel chan<- Progress) ProgressFunc {
+	return func(progress Progress) {
+		select {
+		case channel <- progress:
+		default:
+		}
+	}
+}
+
+type progressKey struct{}
+
+func WithProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+func GetProgress(ctx context.Context) (ProgressFunc, bool) {
+	progress, ok := ctx.Value(progressKey{}).(ProgressFunc)
+	return progress, ok && progress != nil
+}
+
+func trackProgress(progress ProgressFunc, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		var total int64 = -1
+		if req.ContentLength > 0 {
+			total = req.ContentLength
+		}
+
+		req = req.Clone(req.Context())
+		req.Body = newProgressReader(req.Body, ProgressUpload, total, progress)
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		var total int64 = -1
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+
+		resp.Body = newProgressReader(resp.Body, ProgressDownload, total, progress)
+	}
+
+	return resp, nil
+}
+
+type progressReader struct {
+	reader    io.ReadCloser
+	direction string
+	total     int64
+	progress  ProgressFunc
+	bytes     int64
+	start     time.Time
+	reported  time.Time
+	done      bool
+	mutex     sync.Mutex
+}
+
+func (reader *progressReader) Read(p []byte) (int, error) {
+	n, err := reader.reader.Read(p)
+
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+
+	reader.bytes += int64(n)
+
+	if err == io.EOF {
+		reader.report(true)
+	} else if n > 0 && time.Since(reader.reported) >= 100*time.Millisecond {
+		reader.report(false)
+	}
+
+	return n, err
+}
+
+func (reader *progressReader) Close() error {
+	reader.mutex.Lock()
+	if reader.total >= 0 && reader.bytes >= reader.total {
+		reader.report(true)
+	}
+	reader.mutex.Unlock()
+
+	return reader.reader.Close()
+}
+
+func (reader *progressReader) report(done bool) {
+	if reader.done {
+		return
+	}
+
+	var rate float64
+	var elapsed = time.Since(reader.start).Seconds()
+	if elapsed > 0 {
+		rate = float64(reader.bytes) / elapsed
+	}
+
+	reader.done = done
+	reader.reported = time.Now()
+	reader.progress(Progress{
+		Direction: reader.direction,
+		Bytes:     reader.bytes,
+		Total:     reader.total,
+		Rate:      rate,
+		Done:      done,
+	})
+}
+
+func newProgressReader(reader io.ReadCloser, direction string, total int64, progress ProgressFunc) *progressReader {
+	return &progressReader{
+		reader:    reader,
+		direction: direction,
+		total:     total,
+		progress:  progress,
+		start:     time.Now(),
+	}
+}
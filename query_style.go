@@ -0,0 +1,27 @@
+package sdkgen
+
+const (
+	QueryStyleForm           = "form"
+	QueryStyleSpaceDelimited = "spaceDelimited"
+	QueryStylePipeDelimited  = "pipeDelimited"
+	QueryStyleDeepObject     = "deepObject"
+	QueryStyleBracket        = "bracket"
+)
+
+// QueryStyle describes how a query parameter is serialized, the style follows the OpenAPI specification with the
+// additional bracket style which serializes arrays as ids[]=1&ids[]=2
+type QueryStyle struct {
+	Style   string
+	Explode bool
+}
+
+func (style QueryStyle) getDelimiter() string {
+	switch style.Style {
+	case QueryStyleSpaceDelimited:
+		return " "
+	case QueryStylePipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
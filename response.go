@@ -0,0 +1,112 @@
+package sdkgen
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// ResponseTooLargeError is returned in case a response body exceeds the configured maximum response size
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return "the response body exceeds the maximum size of " + strconv.FormatInt(e.Limit, 10) + " bytes"
+}
+
+// DecodeJson decodes the JSON value of the body directly from the stream instead of buffering the complete body, like
+// at json.Unmarshal it is an error if the body contains additional data after the JSON value
+func DecodeJson(body io.Reader, target interface{}) error {
+	return NewJsonCodec().decode(body, target)
+}
+
+func DecodeJsonResponse[T any](resp *http.Response) (T, error) {
+	var data T
+	err := DecodeJson(resp.Body, &data)
+
+	return data, err
+}
+
+// StreamResponse returns the body of the response to the caller who is responsible to close it, in case the status
+// code is not successful the body is closed and an error is returned
+func StreamResponse(resp *http.Response) (io.ReadCloser, error) {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+
+	resp.Body.Close()
+
+	return nil, errors.New("The server returned an unknown status code: " + strconv.Itoa(resp.StatusCode))
+}
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a context which stores the header of the response into the provided header, this can be
+// used to obtain i.e. the Link header of a generated operation
+func WithResponseHeader(ctx context.Context, header *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, header)
+}
+
+func getResponseHeader(ctx context.Context) (*http.Header, bool) {
+	header, ok := ctx.Value(responseHeaderKey{}).(*http.Header)
+	return header, ok && header != nil
+}
+
+func captureResponseHeader(header *http.Header, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+
+	*header = resp.Header.Clone()
+
+	return resp, nil
+}
+
+func limitResponse(limit int64, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	resp, err := next(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.ContentLength > limit {
+		resp.Body.Close()
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+
+	resp.Body = &limitedBody{reader: resp.Body, remaining: limit, limit: limit}
+
+	return resp, nil
+}
+
+type limitedBody struct {
+	reader    io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (body *limitedBody) Read(p []byte) (int, error) {
+	if body.remaining < 0 {
+		return 0, &ResponseTooLargeError{Limit: body.limit}
+	}
+
+	// we read one byte more than allowed so that we can detect whether the body exceeds the limit
+	if int64(len(p)) > body.remaining+1 {
+		p = p[:body.remaining+1]
+	}
+
+	n, err := body.reader.Read(p)
+	body.remaining -= int64(n)
+	if body.remaining < 0 {
+		return n + int(body.remaining), &ResponseTooLargeError{Limit: body.limit}
+	}
+
+	return n, err
+}
+
+func (body *limitedBody) Close() error {
+	return body.reader.Close()
+}
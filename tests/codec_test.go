@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type CountingCodec struct {
+	sdkgen.JsonCodec
+	Encoded int
+	Decoded int
+}
+
+func (codec *CountingCodec) Encode(value interface{}) ([]byte, error) {
+	codec.Encoded++
+	return codec.JsonCodec.Encode(value)
+}
+
+func (codec *CountingCodec) Decode(body io.Reader, target interface{}) error {
+	codec.Decoded++
+	return codec.JsonCodec.Decode(body, target)
+}
+
+func TestCodecStrictError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"args":{},"data":"","form":{},"headers":{},"json":{"int":1,"object":{"id":1,"name":"foo","extra":true}},"method":"GET","origin":"127.0.0.1"}`))
+	}))
+	defer server.Close()
+
+	client, err := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Codec: &sdkgen.JsonCodec{Strict: sdkgen.StrictModeError},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := client.Product().GetAll(0, 16, "")
+
+	var mismatchError *sdkgen.SchemaMismatchError
+	if !errors.As(err, &mismatchError) {
+		t.Fatalf("expected a schema mismatch error, got %v", err)
+	}
+
+	AssertEquals(t, response.Method, "GET")
+	AssertEquals(t, err.Error(), "the response does not match the model: unknown field json.object.extra, missing field json.float, missing field json.string, missing field json.bool, missing field json.dateString, missing field json.dateTimeString, missing field json.timeString, missing field json.arrayScalar, missing field json.arrayObject, missing field json.mapScalar, missing field json.mapObject, unknown field origin, missing field files")
+}
+
+func TestCodecStrictWarn(t *testing.T) {
+	var codec = &sdkgen.JsonCodec{Strict: sdkgen.StrictModeWarn}
+
+	var mismatches []sdkgen.SchemaMismatch
+	codec.OnMismatch = func(result []sdkgen.SchemaMismatch) {
+		mismatches = append(mismatches, result...)
+	}
+
+	var objects []generated.TestObject
+	err := codec.Decode(strings.NewReader(`[{"id":1,"name":"foo","Extra":1},{"ID":2}]`), &objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, objects[1].Name, "")
+	if len(mismatches) != 2 {
+		t.Fatalf("expected two mismatches, got %v", mismatches)
+	}
+
+	AssertEquals(t, mismatches[0].String(), "unknown field [0].Extra")
+	AssertEquals(t, mismatches[1].String(), "missing field [1].name")
+
+	mismatches = nil
+	var objectMap map[string]generated.TestObject
+	err = codec.Decode(strings.NewReader(`{"foo":{"id":1,"name":"foo"}}`), &objectMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %v", mismatches)
+	}
+}
+
+func TestCodecUseNumber(t *testing.T) {
+	var parser = &sdkgen.Parser{Codec: &sdkgen.JsonCodec{UseNumber: true}}
+
+	var model interface{}
+	err := parser.Parse(`{"id":9007199254740993}`, &model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, model.(map[string]interface{})["id"].(json.Number).String(), "9007199254740993")
+
+	err = sdkgen.NewParser("").Parse(`{"id":9007199254740993}`, &model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if model.(map[string]interface{})["id"].(float64) != 9007199254740992 {
+		t.Error("expected that the default codec decodes numbers as float64")
+	}
+}
+
+func TestCodecCustom(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"data": string(body)})
+	}))
+	defer server.Close()
+
+	var codec = &CountingCodec{}
+	client, err := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{Codec: codec})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := client.Product().Create(NewPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Data[0:20], `{"int":1337,"float":`)
+	if codec.Encoded != 1 || codec.Decoded != 1 {
+		t.Errorf("expected that the payload and response went through the codec, got %d and %d", codec.Encoded, codec.Decoded)
+	}
+}
+
+func TestCodecClient(t *testing.T) {
+	client, err := sdkgen.NewClient("https://api.acme.com", sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var codec = &sdkgen.JsonCodec{UseNumber: true}
+	client.SetCodec(codec)
+
+	if client.GetCodec() != codec {
+		t.Error("expected that the client returns the configured codec")
+	}
+
+	var model interface{}
+	err = client.Parser.Parse(`{"id":9007199254740993}`, &model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, model.(map[string]interface{})["id"].(json.Number).String(), "9007199254740993")
+}
+
+func TestCodecStrictTrailingData(t *testing.T) {
+	var codec = &sdkgen.JsonCodec{Strict: sdkgen.StrictModeError}
+
+	var object generated.TestObject
+	err := codec.Decode(strings.NewReader(`{"id":1,"name":"foo"} {"id":2}`), &object)
+	if err == nil {
+		t.Error("expected an error for data after the top-level value")
+	}
+}
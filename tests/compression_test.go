@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected a gzip compressed request body, got %q", r.Header.Get("Content-Encoding"))
+		}
+
+		var payload generated.TestRequest
+		reader, err := gzip.NewReader(r.Body)
+		if err == nil {
+			err = json.NewDecoder(reader).Decode(&payload)
+		}
+
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+
+		writer := gzip.NewWriter(w)
+		json.NewEncoder(writer).Encode(generated.TestResponse{
+			Method:  r.Method,
+			Data:    payload.String,
+			Headers: &generated.TestMapScalar{"Accept-Encoding": r.Header.Get("Accept-Encoding")},
+		})
+		writer.Close()
+	}))
+	defer server.Close()
+
+	var compression = sdkgen.NewCompression()
+	compression.Threshold = 0
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.HttpBearer{Token: "my_token"}, sdkgen.ClientOptions{
+		Transport:   &http.Transport{DisableCompression: true},
+		Compression: compression,
+	})
+
+	response, err := client.Product().Create(NewPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := *response.Headers
+
+	AssertEquals(t, response.Method, "POST")
+	AssertEquals(t, response.Data, "foobar")
+	AssertEquals(t, headers["Accept-Encoding"], "gzip, deflate")
+}
+
+func TestCompressionThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generated.TestResponse{
+			Data:    string(raw),
+			Headers: &generated.TestMapScalar{"Content-Encoding": r.Header.Get("Content-Encoding")},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Compression: sdkgen.NewCompression(),
+	})
+
+	response, err := client.Product().Text("foobar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := *response.Headers
+
+	AssertEquals(t, response.Data, "foobar")
+	AssertEquals(t, headers["Content-Encoding"], "")
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"testing"
+	"time"
+)
+
+type Schedule struct {
+	Date     sdkgen.Date      `json:"date"`
+	Time     sdkgen.Time      `json:"time"`
+	DateTime sdkgen.DateTime  `json:"dateTime"`
+	Optional *sdkgen.DateTime `json:"optional"`
+}
+
+func TestDateJson(t *testing.T) {
+	var raw = `{"date":"2024-09-22","time":"10:09:00.5","dateTime":"2024-09-22T10:09:00+02:00","optional":null}`
+
+	var schedule Schedule
+	err := json.Unmarshal([]byte(raw), &schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, schedule.Date.String(), "2024-09-22")
+	AssertEquals(t, schedule.Time.String(), "10:09:00.5")
+	AssertEquals(t, schedule.DateTime.String(), "2024-09-22T10:09:00+02:00")
+	AssertEquals(t, schedule.DateTime.UTC().Format(time.RFC3339), "2024-09-22T08:09:00Z")
+
+	if schedule.Optional != nil {
+		t.Error("expected no optional date-time")
+	}
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(data), raw)
+}
+
+func TestDateParse(t *testing.T) {
+	_, err := sdkgen.ParseDate("2024-09-22T10:09:00Z")
+	if err == nil {
+		t.Error("expected an error for a date-time")
+	}
+
+	_, err = sdkgen.ParseTime("25:00:00")
+	if err == nil {
+		t.Error("expected an error for an invalid hour")
+	}
+
+	var schedule Schedule
+	err = json.Unmarshal([]byte(`{"date":"22.09.2024"}`), &schedule)
+	if err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+
+	_, err = sdkgen.ParseDateTime("2024-09-22T10:09:00")
+	if err == nil {
+		t.Error("expected an error for a date-time without offset")
+	}
+
+	dateTime, err := sdkgen.ParseDateTime("2024-09-22T10:09:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, dateTime.String(), "2024-09-22T10:09:00Z")
+}
+
+func TestDateConversion(t *testing.T) {
+	var location = time.FixedZone("CEST", 2*60*60)
+	var now = time.Date(2024, 9, 22, 23, 30, 15, 0, location)
+
+	var date = sdkgen.DateOf(now)
+	var timeOfDay = sdkgen.TimeOf(now)
+
+	AssertEquals(t, date.String(), "2024-09-22")
+	AssertEquals(t, timeOfDay.String(), "23:30:15")
+	AssertEquals(t, sdkgen.NewDateTime(now).String(), "2024-09-22T23:30:15+02:00")
+
+	if !timeOfDay.ToTime(date, location).Equal(now) {
+		t.Errorf("expected %s, got %s", now, timeOfDay.ToTime(date, location))
+	}
+
+	AssertEquals(t, date.ToTime(location).Format(time.RFC3339), "2024-09-22T00:00:00+02:00")
+
+	var parameters = make(map[string]interface{})
+	parameters["date"] = date
+	parameters["time"] = timeOfDay
+	parameters["dateTime"] = sdkgen.NewDateTime(now)
+
+	result := sdkgen.NewParser("https://api.acme.com").Query(parameters)
+
+	AssertEquals(t, result.Encode(), "date=2024-09-22&dateTime=2024-09-22T23%3A30%3A15%2B02%3A00&time=23%3A30%3A15")
+}
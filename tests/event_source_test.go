@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventReader(t *testing.T) {
+	var stream = ": keep-alive\r\n\r\nevent: update\r\nid: 1\r\ndata: {\"id\":1,\r\ndata: \"name\":\"foo\"}\r\n\r\ndata:bar\rretry: 500\r\r: incomplete\ndata: baz"
+
+	var reader = sdkgen.NewEventReader(strings.NewReader(stream))
+
+	comment, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !comment.IsComment() {
+		t.Errorf("expected a comment, got %+v", comment)
+	}
+
+	AssertEquals(t, comment.Comment, "keep-alive")
+
+	update, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object, err := sdkgen.DecodeEvent[generated.TestObject](update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, update.Event, "update")
+	AssertEquals(t, update.Id, "1")
+	AssertEquals(t, object.Name, "foo")
+
+	message, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, message.Event, "")
+	AssertEquals(t, message.Id, "1")
+	AssertEquals(t, message.Data, "bar")
+
+	if message.Retry != 500*time.Millisecond {
+		t.Errorf("expected a retry of 500ms, got %s", message.Retry)
+	}
+
+	_, err = reader.Next()
+	if err != io.EOF {
+		t.Errorf("expected the incomplete event to be discarded, got %v", err)
+	}
+}
+
+func TestEventReaderRetryOnly(t *testing.T) {
+	var reader = sdkgen.NewEventReader(strings.NewReader("retry: 10000\n\n"))
+
+	_, err := reader.Next()
+	if err != io.EOF {
+		t.Errorf("expected no event, got %v", err)
+	}
+
+	if reader.GetRetry() != 10*time.Second {
+		t.Errorf("expected a retry of 10s, got %s", reader.GetRetry())
+	}
+}
+
+func TestEventSourceRetryOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "retry: 10000\n\n")
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var source = sdkgen.NewEventSource(client.HttpClient, server.URL)
+	err := source.Subscribe(ctx, func(event sdkgen.Event) error {
+		return nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected an exceeded deadline, got %v", err)
+	}
+
+	if source.RetryInterval != 10*time.Second {
+		t.Errorf("expected a retry interval of 10s, got %s", source.RetryInterval)
+	}
+}
+
+func TestEventSource(t *testing.T) {
+	var lastEventIds []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIds = append(lastEventIds, r.Header.Get("Last-Event-ID"))
+
+		AssertEquals(t, r.Header.Get("Accept"), "text/event-stream")
+		AssertEquals(t, r.Header.Get("Authorization"), "Bearer my_token")
+
+		var id = len(lastEventIds)
+		if id > 2 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "retry: 10\nid: %d\ndata: {\"id\":%d}\n\n", id, id)
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClient(server.URL, sdkgen.HttpBearer{Token: "my_token"})
+
+	var ids []int
+	var source = sdkgen.NewEventSource(client.HttpClient, server.URL+"/events")
+	err := source.Subscribe(context.Background(), func(event sdkgen.Event) error {
+		object, err := sdkgen.DecodeEvent[generated.TestObject](event)
+		ids = append(ids, object.Id)
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("got unexpected events %v", ids)
+	}
+
+	if len(lastEventIds) != 3 || lastEventIds[0] != "" || lastEventIds[1] != "1" || lastEventIds[2] != "2" {
+		t.Errorf("got unexpected last event ids %v", lastEventIds)
+	}
+}
+
+func TestEventSourceCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: foo\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var source = sdkgen.NewEventSource(client.HttpClient, server.URL)
+	err := source.Subscribe(ctx, func(event sdkgen.Event) error {
+		AssertEquals(t, event.Data, "foo")
+		cancel()
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled context, got %v", err)
+	}
+}
+
+func TestEventSourceAttempt(t *testing.T) {
+	var requests = 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 3 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "retry: 10\n\n")
+	}))
+	defer server.Close()
+
+	var logger = &RecordingLogger{}
+	var metrics = sdkgen.NewMemoryMetrics()
+
+	client, _ := sdkgen.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Logging: sdkgen.NewLogging(logger),
+		Metrics: metrics,
+	})
+
+	var source = sdkgen.NewEventSource(client.HttpClient, server.URL)
+	err := source.Subscribe(context.Background(), func(event sdkgen.Event) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.Fields) != 4 || logger.Fields[0]["attempt"] != 1 || logger.Fields[3]["attempt"] != 4 {
+		t.Errorf("got unexpected log entries %v", logger.Fields)
+	}
+
+	if metrics.GetRetryCount(sdkgen.Operation{}) != 3 {
+		t.Errorf("expected 3 retries, got %d", metrics.GetRetryCount(sdkgen.Operation{}))
+	}
+}
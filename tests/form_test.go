@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type FormEvent struct {
+	Name     string                `json:"name"`
+	Start    time.Time             `json:"start"`
+	Day      sdkgen.Date           `json:"day"`
+	Ids      []int64               `json:"ids"`
+	Owner    *generated.TestObject `json:"owner"`
+	Disabled *bool                 `json:"disabled"`
+}
+
+func TestFormEncode(t *testing.T) {
+	var codec = sdkgen.NewFormCodec()
+
+	values, err := codec.EncodeValues(NewPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, DecodeForm(values), "arrayObject[0][id]=1&arrayObject[0][name]=foo&arrayObject[1][id]=2&arrayObject[1][name]=bar&arrayScalar=foo&arrayScalar=bar&bool=1&dateString=2024-09-22&dateTimeString=2024-09-22T10:09:00Z&float=13.37&int=1337&mapObject[bar][id]=2&mapObject[bar][name]=bar&mapObject[foo][id]=1&mapObject[foo][name]=foo&mapScalar[bar]=foo&mapScalar[foo]=bar&object[id]=1&object[name]=foo&string=foobar&timeString=10:09:00")
+
+	codec.Notation = sdkgen.FormNotationDot
+	codec.ArrayStyle = sdkgen.QueryStyle{Style: sdkgen.QueryStyleBracket}
+
+	values, err = codec.EncodeValues(NewPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, DecodeForm(values), "arrayObject[0].id=1&arrayObject[0].name=foo&arrayObject[1].id=2&arrayObject[1].name=bar&arrayScalar[]=foo&arrayScalar[]=bar&bool=1&dateString=2024-09-22&dateTimeString=2024-09-22T10:09:00Z&float=13.37&int=1337&mapObject.bar.id=2&mapObject.bar.name=bar&mapObject.foo.id=1&mapObject.foo.name=foo&mapScalar.bar=foo&mapScalar.foo=bar&object.id=1&object.name=foo&string=foobar&timeString=10:09:00")
+
+	codec.ArrayStyle = sdkgen.QueryStyle{Style: sdkgen.QueryStylePipeDelimited}
+
+	raw, err := codec.Encode(FormEvent{Name: "foo bar", Start: time.Date(2024, 9, 22, 10, 9, 0, 0, time.UTC), Day: sdkgen.NewDate(2024, 9, 22), Ids: []int64{9007199254740993, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), "day=2024-09-22&ids=9007199254740993%7C2&name=foo+bar&start=2024-09-22T10%3A09%3A00Z")
+
+	_, err = codec.Encode("foo")
+	if err == nil {
+		t.Error("expected an error for a scalar value")
+	}
+}
+
+func TestFormDecode(t *testing.T) {
+	var codecs = []*sdkgen.FormCodec{
+		sdkgen.NewFormCodec(),
+		{Notation: sdkgen.FormNotationDot},
+		{Notation: sdkgen.FormNotationDot, ArrayStyle: sdkgen.QueryStyle{Style: sdkgen.QueryStyleBracket}},
+		{ArrayStyle: sdkgen.QueryStyle{Style: sdkgen.QueryStyleSpaceDelimited}},
+	}
+
+	for _, codec := range codecs {
+		var payload = NewPayload()
+
+		values, err := codec.EncodeValues(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result generated.TestRequest
+		err = codec.DecodeValues(values, &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(result, payload) {
+			t.Errorf("%s: got %+v, wanted %+v", codec.Notation, result, payload)
+		}
+
+		var event = FormEvent{Name: "foo", Start: time.Date(2024, 9, 22, 10, 9, 0, 0, time.UTC), Day: sdkgen.NewDate(2024, 9, 22), Ids: []int64{9007199254740993}, Owner: &generated.TestObject{Id: 1, Name: "bar"}}
+		values, err = codec.EncodeValues(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var eventResult FormEvent
+		err = codec.DecodeValues(values, &eventResult)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(eventResult, event) {
+			t.Errorf("%s: got %+v, wanted %+v", codec.Notation, eventResult, event)
+		}
+	}
+
+	var result FormEvent
+	err := sdkgen.NewFormCodec().DecodeValues(url.Values{"ids": {"foo"}}, &result)
+	if err == nil {
+		t.Error("expected an error for an invalid number")
+	}
+}
+
+func TestFormOAuth2(t *testing.T) {
+	var forms []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		forms = append(forms, r.Header.Get("Content-Type")+" "+r.PostForm.Encode())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token_type":"bearer","access_token":"my_token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	var authenticator = &sdkgen.OAuth2Authenticator{
+		Credentials: sdkgen.OAuth2{
+			ClientId:     "foo",
+			ClientSecret: "bar",
+			TokenUrl:     server.URL + "/token",
+			Scopes:       []string{"a", "b"},
+			TokenStore:   sdkgen.NewMemoryTokenStore(),
+		},
+	}
+
+	_, _ = authenticator.FetchAccessTokenByClientCredentials()
+	_, _ = authenticator.FetchAccessTokenByCode("my_code")
+	_, _ = authenticator.FetchAccessTokenByRefresh("my_refresh")
+
+	if len(forms) != 3 {
+		t.Fatalf("expected three token requests, got %d", len(forms))
+	}
+
+	AssertEquals(t, forms[0], "application/x-www-form-urlencoded grant_type=client_credentials&scope=a%2Cb")
+	AssertEquals(t, forms[1], "application/x-www-form-urlencoded code=my_code&grant_type=authorization_code")
+	AssertEquals(t, forms[2], "application/x-www-form-urlencoded grant_type=refresh_token&refresh_token=my_refresh")
+}
+
+func DecodeForm(values url.Values) string {
+	result, _ := url.QueryUnescape(values.Encode())
+	return result
+}
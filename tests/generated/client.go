@@ -37,6 +37,17 @@ func NewClientWithVersion(baseUrl string, credentials sdkgen.CredentialsInterfac
 	}, nil
 }
 
+func NewClientWithOptions(baseUrl string, credentials sdkgen.CredentialsInterface, options sdkgen.ClientOptions) (*Client, error) {
+	var client, err = sdkgen.NewClientWithOptions(baseUrl, credentials, options)
+	if err != nil {
+		return &Client{}, err
+	}
+
+	return &Client{
+		internal: client,
+	}, nil
+}
+
 func Build(token string) (*Client, error) {
 	var credentials = sdkgen.HttpBearer{Token: token}
 
@@ -5,7 +5,7 @@ package generated
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/apioo/sdkgen-go/v2"
@@ -21,6 +21,11 @@ type ProductTag struct {
 
 // GetAll Returns a collection
 func (client *ProductTag) GetAll(startIndex int, count int, search string) (TestResponse, error) {
+	return client.GetAllWithContext(context.Background(), startIndex, count, search)
+}
+
+// GetAllWithContext Returns a collection
+func (client *ProductTag) GetAllWithContext(ctx context.Context, startIndex int, count int, search string) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
@@ -29,34 +34,49 @@ func (client *ProductTag) GetAll(startIndex int, count int, search string) (Test
 	queryParams["search"] = search
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
-
-	req, err := http.NewRequest("GET", u.String(), nil)
+	u, err := url.Parse(rawUrl)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	resp, err := client.internal.HttpClient.Do(req)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	defer resp.Body.Close()
+	u.RawQuery = query.Encode()
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.getAll",
+		Tag:      "ProductTag",
+		Name:     "GetAll",
+		Method:   "GET",
+		Path:     "/anything",
+		Security: []string{"bearer"},
+	})
 
-	respBody, err := io.ReadAll(resp.Body)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	resp, err := client.internal.HttpClient.Do(req)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -67,48 +87,74 @@ func (client *ProductTag) GetAll(startIndex int, count int, search string) (Test
 
 // Create Creates a new product
 func (client *ProductTag) Create(payload TestRequest) (TestResponse, error) {
+	return client.CreateWithContext(context.Background(), payload)
+}
+
+// CreateWithContext Creates a new product
+func (client *ProductTag) CreateWithContext(ctx context.Context, payload TestRequest) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	raw, err := json.Marshal(payload)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	var reqBody = bytes.NewReader(raw)
+	u.RawQuery = query.Encode()
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	err = sdkgen.Validate(payload)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	raw, err := client.internal.Parser.Encode(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	resp, err := client.internal.HttpClient.Do(req)
+	var reqBody = bytes.NewReader(raw)
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.create",
+		Tag:         "ProductTag",
+		Name:        "Create",
+		Method:      "POST",
+		Path:        "/anything",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", "application/json")
 
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := client.internal.HttpClient.Do(req)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -116,7 +162,7 @@ func (client *ProductTag) Create(payload TestRequest) (TestResponse, error) {
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return TestResponse{}, &TestResponseException{
 			Payload:  data,
@@ -129,49 +175,74 @@ func (client *ProductTag) Create(payload TestRequest) (TestResponse, error) {
 
 // Update Updates an existing product
 func (client *ProductTag) Update(id int, payload TestRequest) (TestResponse, error) {
+	return client.UpdateWithContext(context.Background(), id, payload)
+}
+
+// UpdateWithContext Updates an existing product
+func (client *ProductTag) UpdateWithContext(ctx context.Context, id int, payload TestRequest) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 	pathParams["id"] = id
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/:id", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/:id", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	raw, err := json.Marshal(payload)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	var reqBody = bytes.NewReader(raw)
+	u.RawQuery = query.Encode()
 
-	req, err := http.NewRequest("PUT", u.String(), reqBody)
+	err = sdkgen.Validate(payload)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	raw, err := client.internal.Parser.Encode(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	resp, err := client.internal.HttpClient.Do(req)
+	var reqBody = bytes.NewReader(raw)
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.update",
+		Tag:      "ProductTag",
+		Name:     "Update",
+		Method:   "PUT",
+		Path:     "/anything/:id",
+		Security: []string{"bearer"},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", "application/json")
 
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := client.internal.HttpClient.Do(req)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -182,28 +253,58 @@ func (client *ProductTag) Update(id int, payload TestRequest) (TestResponse, err
 
 // Patch Patches an existing product
 func (client *ProductTag) Patch(id int, payload TestRequest) (TestResponse, error) {
+	return client.PatchWithContext(context.Background(), id, payload)
+}
+
+// PatchWithContext Patches an existing product
+func (client *ProductTag) PatchWithContext(ctx context.Context, id int, payload TestRequest) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 	pathParams["id"] = id
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/:id", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/:id", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	raw, err := json.Marshal(payload)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u.RawQuery = query.Encode()
+
+	err = sdkgen.Validate(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	raw, err := client.internal.Parser.Encode(payload)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
 	var reqBody = bytes.NewReader(raw)
 
-	req, err := http.NewRequest("PATCH", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.patch",
+		Tag:      "ProductTag",
+		Name:     "Patch",
+		Method:   "PATCH",
+		Path:     "/anything/:id",
+		Security: []string{"bearer"},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
@@ -217,14 +318,87 @@ func (client *ProductTag) Patch(id int, payload TestRequest) (TestResponse, erro
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		var data TestResponse
+		err := client.internal.Parser.Decode(resp.Body, &data)
+
+		return data, err
+	}
+
+	var statusCode = resp.StatusCode
+	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
+}
+
+// MergePatch Patches an existing product and sends only the fields which were set
+func (client *ProductTag) MergePatch(id int, payload TestRequestPatch) (TestResponse, error) {
+	return client.MergePatchWithContext(context.Background(), id, payload)
+}
+
+// MergePatchWithContext Patches an existing product and sends only the fields which were set
+func (client *ProductTag) MergePatchWithContext(ctx context.Context, id int, payload TestRequestPatch) (TestResponse, error) {
+	pathParams := make(map[string]interface{})
+	pathParams["id"] = id
+
+	queryParams := make(map[string]interface{})
+
+	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/:id", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u.RawQuery = query.Encode()
+
+	err = sdkgen.Validate(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	raw, err := client.internal.Parser.Encode(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	var reqBody = bytes.NewReader(raw)
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.mergePatch",
+		Tag:      "ProductTag",
+		Name:     "MergePatch",
+		Method:   "PATCH",
+		Path:     "/anything/:id",
+		Security: []string{"bearer"},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", u.String(), reqBody)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	resp, err := client.internal.HttpClient.Do(req)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -235,40 +409,60 @@ func (client *ProductTag) Patch(id int, payload TestRequest) (TestResponse, erro
 
 // Delete Deletes an existing product
 func (client *ProductTag) Delete(id int) (TestResponse, error) {
+	return client.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext Deletes an existing product
+func (client *ProductTag) DeleteWithContext(ctx context.Context, id int) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 	pathParams["id"] = id
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/:id", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/:id", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
-
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+	u, err := url.Parse(rawUrl)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	resp, err := client.internal.HttpClient.Do(req)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	defer resp.Body.Close()
+	u.RawQuery = query.Encode()
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.delete",
+		Tag:      "ProductTag",
+		Name:     "Delete",
+		Method:   "DELETE",
+		Path:     "/anything/:id",
+		Security: []string{"bearer"},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), nil)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := client.internal.HttpClient.Do(req)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -279,22 +473,48 @@ func (client *ProductTag) Delete(id int) (TestResponse, error) {
 
 // Binary Test binary content type
 func (client *ProductTag) Binary(payload []byte) (TestResponse, error) {
+	return client.BinaryWithContext(context.Background(), payload)
+}
+
+// BinaryWithContext Test binary content type
+func (client *ProductTag) BinaryWithContext(ctx context.Context, payload []byte) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/binary", pathParams)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/binary", pathParams))
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u.RawQuery = query.Encode()
 
 	var reqBody = bytes.NewReader(payload)
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.binary",
+		Tag:         "ProductTag",
+		Name:        "Binary",
+		Method:      "POST",
+		Path:        "/anything/binary",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
@@ -308,22 +528,16 @@ func (client *ProductTag) Binary(payload []byte) (TestResponse, error) {
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return TestResponse{}, err
-	}
-
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
 
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
-		var data = respBody
-		err = nil
+		data, err := io.ReadAll(resp.Body)
 
 		return TestResponse{}, &BinaryException{
 			Payload:  data,
@@ -334,24 +548,123 @@ func (client *ProductTag) Binary(payload []byte) (TestResponse, error) {
 	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
 }
 
+// Download Returns the binary content of a product, the caller must close the returned body
+func (client *ProductTag) Download(id int) (io.ReadCloser, error) {
+	return client.DownloadWithContext(context.Background(), id)
+}
+
+// DownloadWithContext Returns the binary content of a product, the caller must close the returned body
+func (client *ProductTag) DownloadWithContext(ctx context.Context, id int) (io.ReadCloser, error) {
+	pathParams := make(map[string]interface{})
+	pathParams["id"] = id
+
+	queryParams := make(map[string]interface{})
+
+	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/:id/download", pathParams)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return nil, err
+	}
+
+	u.RawQuery = query.Encode()
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.download",
+		Tag:         "ProductTag",
+		Name:        "Download",
+		Method:      "GET",
+		Path:        "/anything/:id/download",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := client.internal.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+
+	defer resp.Body.Close()
+
+	var statusCode = resp.StatusCode
+	if statusCode == 500 {
+		data, err := io.ReadAll(resp.Body)
+
+		return nil, &BinaryException{
+			Payload:  data,
+			Previous: err,
+		}
+	}
+
+	return nil, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
+}
+
 // Form Test form content type
 func (client *ProductTag) Form(payload url.Values) (TestResponse, error) {
+	return client.FormWithContext(context.Background(), payload)
+}
+
+// FormWithContext Test form content type
+func (client *ProductTag) FormWithContext(ctx context.Context, payload url.Values) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/form", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/form", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u.RawQuery = query.Encode()
 
 	var reqBody = strings.NewReader(payload.Encode())
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.form",
+		Tag:         "ProductTag",
+		Name:        "Form",
+		Method:      "POST",
+		Path:        "/anything/form",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
@@ -365,20 +678,20 @@ func (client *ProductTag) Form(payload url.Values) (TestResponse, error) {
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return TestResponse{}, err
-	}
-
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
 
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return TestResponse{}, err
+		}
+
 		data, err := url.ParseQuery(string(respBody))
 
 		return TestResponse{}, &FormException{
@@ -390,34 +703,64 @@ func (client *ProductTag) Form(payload url.Values) (TestResponse, error) {
 	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
 }
 
-// Json Test json content type
-func (client *ProductTag) Json(payload any) (TestResponse, error) {
+// FormObject Test typed form content type
+func (client *ProductTag) FormObject(payload TestObject) (TestResponse, error) {
+	return client.FormObjectWithContext(context.Background(), payload)
+}
+
+// FormObjectWithContext Test typed form content type
+func (client *ProductTag) FormObjectWithContext(ctx context.Context, payload TestObject) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/form", pathParams)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/json", pathParams))
+	u, err := url.Parse(rawUrl)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	raw, err := json.Marshal(payload)
+	u.RawQuery = query.Encode()
+
+	err = sdkgen.Validate(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	raw, err := client.internal.Parser.GetCodecFor("application/x-www-form-urlencoded").Encode(payload)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
 	var reqBody = bytes.NewReader(raw)
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:       "product.formObject",
+		Tag:      "ProductTag",
+		Name:     "FormObject",
+		Method:   "POST",
+		Path:     "/anything/form",
+		Security: []string{"bearer"},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := client.internal.HttpClient.Do(req)
 	if err != nil {
@@ -426,14 +769,82 @@ func (client *ProductTag) Json(payload any) (TestResponse, error) {
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		var data TestResponse
+		err := client.internal.Parser.Decode(resp.Body, &data)
+
+		return data, err
+	}
+
+	var statusCode = resp.StatusCode
+	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
+}
+
+// Json Test json content type
+func (client *ProductTag) Json(payload any) (TestResponse, error) {
+	return client.JsonWithContext(context.Background(), payload)
+}
+
+// JsonWithContext Test json content type
+func (client *ProductTag) JsonWithContext(ctx context.Context, payload any) (TestResponse, error) {
+	pathParams := make(map[string]interface{})
+
+	queryParams := make(map[string]interface{})
+
+	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/json", pathParams)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u, err := url.Parse(rawUrl)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u.RawQuery = query.Encode()
+
+	raw, err := client.internal.Parser.Encode(payload)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	var reqBody = bytes.NewReader(raw)
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.json",
+		Tag:         "ProductTag",
+		Name:        "Json",
+		Method:      "POST",
+		Path:        "/anything/json",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.internal.HttpClient.Do(req)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
@@ -441,7 +852,7 @@ func (client *ProductTag) Json(payload any) (TestResponse, error) {
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
 		var data interface{}
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return TestResponse{}, &JsonException{
 			Payload:  data,
@@ -454,52 +865,72 @@ func (client *ProductTag) Json(payload any) (TestResponse, error) {
 
 // Multipart Test json content type
 func (client *ProductTag) Multipart(payload *sdkgen.Multipart) (TestResponse, error) {
+	return client.MultipartWithContext(context.Background(), payload)
+}
+
+// MultipartWithContext Test json content type
+func (client *ProductTag) MultipartWithContext(ctx context.Context, payload *sdkgen.Multipart) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/multipart", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/multipart", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
-
-	var reqBody = payload.Build()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	req.Header.Set("Content-Type", payload.GetContentType())
+	u.RawQuery = query.Encode()
 
-	resp, err := client.internal.HttpClient.Do(req)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.multipart",
+		Tag:         "ProductTag",
+		Name:        "Multipart",
+		Method:      "POST",
+		Path:        "/anything/multipart",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), nil)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", payload.GetContentType())
+	req.ContentLength = payload.GetContentLength()
+	req.GetBody = payload.GetBody()
+	req.Body = payload.Stream()
 
-	respBody, err := io.ReadAll(resp.Body)
+	resp, err := client.internal.HttpClient.Do(req)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
 
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
-		// @TODO currently not possible, please create an issue at https://github.com/apioo/typeapi if needed
-		var data = &sdkgen.Multipart{}
-		err = nil
+		data, err := sdkgen.ParseMultipart(resp.Header.Get("Content-Type"), resp.Body)
 
 		return TestResponse{}, &MultipartException{
 			Payload:  data,
@@ -512,22 +943,48 @@ func (client *ProductTag) Multipart(payload *sdkgen.Multipart) (TestResponse, er
 
 // Text Test text content type
 func (client *ProductTag) Text(payload string) (TestResponse, error) {
+	return client.TextWithContext(context.Background(), payload)
+}
+
+// TextWithContext Test text content type
+func (client *ProductTag) TextWithContext(ctx context.Context, payload string) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/text", pathParams))
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/text", pathParams)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u.RawQuery = query.Encode()
 
 	var reqBody = strings.NewReader(payload)
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.text",
+		Tag:         "ProductTag",
+		Name:        "Text",
+		Method:      "POST",
+		Path:        "/anything/text",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
@@ -541,22 +998,17 @@ func (client *ProductTag) Text(payload string) (TestResponse, error) {
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return TestResponse{}, err
-	}
-
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		err := client.internal.Parser.Decode(resp.Body, &data)
 
 		return data, err
 	}
 
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
+		respBody, err := io.ReadAll(resp.Body)
 		var data = string(respBody)
-		err = nil
 
 		return TestResponse{}, &TextException{
 			Payload:  data,
@@ -569,22 +1021,48 @@ func (client *ProductTag) Text(payload string) (TestResponse, error) {
 
 // Xml Test xml content type
 func (client *ProductTag) Xml(payload string) (TestResponse, error) {
+	return client.XmlWithContext(context.Background(), payload)
+}
+
+// XmlWithContext Test xml content type
+func (client *ProductTag) XmlWithContext(ctx context.Context, payload string) (TestResponse, error) {
 	pathParams := make(map[string]interface{})
 
 	queryParams := make(map[string]interface{})
 
 	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/xml", pathParams)
+	if err != nil {
+		return TestResponse{}, err
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestResponse{}, err
+	}
 
-	u, err := url.Parse(client.internal.Parser.Url("/anything/xml", pathParams))
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
 	if err != nil {
 		return TestResponse{}, err
 	}
 
-	u.RawQuery = client.internal.Parser.QueryWithStruct(queryParams, queryStructNames).Encode()
+	u.RawQuery = query.Encode()
 
 	var reqBody = strings.NewReader(payload)
 
-	req, err := http.NewRequest("POST", u.String(), reqBody)
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.xml",
+		Tag:         "ProductTag",
+		Name:        "Xml",
+		Method:      "POST",
+		Path:        "/anything/xml",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return TestResponse{}, err
 	}
@@ -598,30 +1076,114 @@ func (client *ProductTag) Xml(payload string) (TestResponse, error) {
 
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		var data TestResponse
+		err := client.internal.Parser.DecodeResponse(resp, &data)
+
+		return data, err
+	}
+
+	var statusCode = resp.StatusCode
+	if statusCode == 500 {
+		var data TestXmlError
+		err := client.internal.Parser.DecodeResponse(resp, &data)
+
+		return TestResponse{}, &TestXmlErrorException{
+			Payload:  data,
+			Previous: err,
+		}
+	}
+
+	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
+}
+
+// XmlObject Sends and receives a typed XML object
+func (client *ProductTag) XmlObject(payload TestXmlObject) (TestXmlObject, error) {
+	return client.XmlObjectWithContext(context.Background(), payload)
+}
+
+// XmlObjectWithContext Sends and receives a typed XML object
+func (client *ProductTag) XmlObjectWithContext(ctx context.Context, payload TestXmlObject) (TestXmlObject, error) {
+	pathParams := make(map[string]interface{})
+
+	queryParams := make(map[string]interface{})
+
+	var queryStructNames []string
+	var queryStyles map[string]sdkgen.QueryStyle
+
+	rawUrl, err := client.internal.Parser.UrlWithError("/anything/xml", pathParams)
 	if err != nil {
-		return TestResponse{}, err
+		return TestXmlObject{}, err
 	}
 
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	query, err := client.internal.Parser.QueryWithStyle(queryParams, queryStructNames, queryStyles)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	u.RawQuery = query.Encode()
+
+	err = sdkgen.Validate(payload)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	raw, err := client.internal.Parser.GetCodecFor("application/xml").Encode(payload)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	var reqBody = bytes.NewReader(raw)
+
+	ctx = sdkgen.WithOperation(ctx, sdkgen.Operation{
+		Id:          "product.xmlObject",
+		Tag:         "ProductTag",
+		Name:        "XmlObject",
+		Method:      "POST",
+		Path:        "/anything/xml",
+		Security:    []string{"bearer"},
+		StatusCodes: []int{500},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", client.internal.Parser.Accept("application/xml", "application/json"))
+
+	resp, err := client.internal.HttpClient.Do(req)
+	if err != nil {
+		return TestXmlObject{}, err
+	}
+
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		var data TestResponse
-		err := json.Unmarshal(respBody, &data)
+		var data TestXmlObject
+		err := client.internal.Parser.DecodeResponse(resp, &data)
 
 		return data, err
 	}
 
 	var statusCode = resp.StatusCode
 	if statusCode == 500 {
-		var data = string(respBody)
-		err = nil
+		var data TestXmlError
+		err := client.internal.Parser.DecodeResponse(resp, &data)
 
-		return TestResponse{}, &XmlException{
+		return TestXmlObject{}, &TestXmlErrorException{
 			Payload:  data,
 			Previous: err,
 		}
 	}
 
-	return TestResponse{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
+	return TestXmlObject{}, errors.New(fmt.Sprint("The server returned an unknown status code: ", statusCode))
 }
 
 func NewProductTag(httpClient *http.Client, parser *sdkgen.Parser) *ProductTag {
@@ -5,5 +5,5 @@ package generated
 
 type TestObject struct {
 	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required,maxLength=64"`
 }
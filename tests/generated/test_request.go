@@ -4,14 +4,14 @@
 package generated
 
 type TestRequest struct {
-	Int            int            `json:"int"`
+	Int            int            `json:"int" validate:"min=0"`
 	Float          float64        `json:"float"`
-	String         string         `json:"string"`
+	String         string         `json:"string" validate:"maxLength=255"`
 	Bool           bool           `json:"bool"`
-	DateString     string         `json:"dateString"`
-	DateTimeString string         `json:"dateTimeString"`
-	TimeString     string         `json:"timeString"`
-	ArrayScalar    []string       `json:"arrayScalar"`
+	DateString     string         `json:"dateString" validate:"format=date"`
+	DateTimeString string         `json:"dateTimeString" validate:"format=date-time"`
+	TimeString     string         `json:"timeString" validate:"format=time"`
+	ArrayScalar    []string       `json:"arrayScalar" validate:"maxItems=16"`
 	ArrayObject    []TestObject   `json:"arrayObject"`
 	MapScalar      *TestMapScalar `json:"mapScalar"`
 	MapObject      *TestMapObject `json:"mapObject"`
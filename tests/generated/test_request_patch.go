@@ -0,0 +1,26 @@
+// test_request_patch automatically generated by SDKgen please do not edit this file manually
+// @see https://sdkgen.app
+
+package generated
+
+import "github.com/apioo/sdkgen-go/v2"
+
+type TestRequestPatch struct {
+	Int            *sdkgen.Nullable[int]           `json:"int,omitempty"`
+	Float          *sdkgen.Nullable[float64]       `json:"float,omitempty"`
+	String         *sdkgen.Nullable[string]        `json:"string,omitempty"`
+	Bool           *sdkgen.Nullable[bool]          `json:"bool,omitempty"`
+	DateString     *sdkgen.Nullable[string]        `json:"dateString,omitempty"`
+	DateTimeString *sdkgen.Nullable[string]        `json:"dateTimeString,omitempty"`
+	TimeString     *sdkgen.Nullable[string]        `json:"timeString,omitempty"`
+	ArrayScalar    *sdkgen.Nullable[[]string]      `json:"arrayScalar,omitempty"`
+	ArrayObject    *sdkgen.Nullable[[]TestObject]  `json:"arrayObject,omitempty"`
+	MapScalar      *sdkgen.Nullable[TestMapScalar] `json:"mapScalar,omitempty"`
+	MapObject      *sdkgen.Nullable[TestMapObject] `json:"mapObject,omitempty"`
+	Object         *sdkgen.Nullable[TestObject]    `json:"object,omitempty"`
+}
+
+func (patch *TestRequestPatch) UnmarshalJSON(data []byte) error {
+	type alias TestRequestPatch
+	return sdkgen.UnmarshalPatch(data, (*alias)(patch))
+}
@@ -0,0 +1,12 @@
+// test_xml_error automatically generated by SDKgen please do not edit this file manually
+// @see https://sdkgen.app
+
+package generated
+
+import "encoding/xml"
+
+type TestXmlError struct {
+	XMLName xml.Name `json:"-" xml:"error"`
+	Code    int      `json:"code" xml:"code,attr"`
+	Message string   `json:"message" xml:"message"`
+}
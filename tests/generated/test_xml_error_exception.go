@@ -1,4 +1,4 @@
-// XmlException automatically generated by SDKgen please do not edit this file manually
+// TestXmlErrorException automatically generated by SDKgen please do not edit this file manually
 // @see https://sdkgen.app
 
 package generated
@@ -8,12 +8,12 @@ import (
 	"fmt"
 )
 
-type XmlException struct {
-	Payload  string
+type TestXmlErrorException struct {
+	Payload  TestXmlError
 	Previous error
 }
 
-func (e *XmlException) Error() string {
+func (e *TestXmlErrorException) Error() string {
 	raw, err := json.Marshal(e.Payload)
 	if err != nil {
 		return "could not marshal provided JSON data"
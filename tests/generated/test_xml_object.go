@@ -0,0 +1,13 @@
+// test_xml_object automatically generated by SDKgen please do not edit this file manually
+// @see https://sdkgen.app
+
+package generated
+
+import "encoding/xml"
+
+type TestXmlObject struct {
+	XMLName xml.Name `json:"-" xml:"urn:sdkgen:test product"`
+	Id      int      `json:"id" xml:"id,attr"`
+	Name    string   `json:"name" xml:"name"`
+	Tags    []string `json:"tags" xml:"tags>tag"`
+}
@@ -38,7 +38,7 @@ func TestClientCreate(t *testing.T) {
 	AssertEquals(t, headers["Accept"], "application/json")
 	AssertEquals(t, headers["User-Agent"], "SDKgen/0.1.0")
 	AssertEquals(t, response.Method, "POST")
-	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
+	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00Z\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
 }
 
 func TestClientUpdate(t *testing.T) {
@@ -53,7 +53,7 @@ func TestClientUpdate(t *testing.T) {
 	AssertEquals(t, headers["Accept"], "application/json")
 	AssertEquals(t, headers["User-Agent"], "SDKgen/0.1.0")
 	AssertEquals(t, response.Method, "PUT")
-	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
+	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00Z\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
 }
 
 func TestClientPatch(t *testing.T) {
@@ -68,7 +68,7 @@ func TestClientPatch(t *testing.T) {
 	AssertEquals(t, headers["Accept"], "application/json")
 	AssertEquals(t, headers["User-Agent"], "SDKgen/0.1.0")
 	AssertEquals(t, response.Method, "PATCH")
-	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
+	AssertJson(t, response.Json, "{\"int\":1337,\"float\":13.37,\"string\":\"foobar\",\"bool\":true,\"dateString\":\"2024-09-22\",\"dateTimeString\":\"2024-09-22T10:09:00Z\",\"timeString\":\"10:09:00\",\"arrayScalar\":[\"foo\",\"bar\"],\"arrayObject\":[{\"id\":1,\"name\":\"foo\"},{\"id\":2,\"name\":\"bar\"}],\"mapScalar\":{\"bar\":\"foo\",\"foo\":\"bar\"},\"mapObject\":{\"bar\":{\"id\":2,\"name\":\"bar\"},\"foo\":{\"id\":1,\"name\":\"foo\"}},\"object\":{\"id\":1,\"name\":\"foo\"}}")
 }
 
 func TestClientDelete(t *testing.T) {
@@ -167,6 +167,23 @@ func TestClientText(t *testing.T) {
 	AssertEquals(t, response.Data, "foobar")
 }
 
+func TestClientFormObject(t *testing.T) {
+	client, _ := generated.Build("my_token")
+
+	response, err := client.Product().FormObject(generated.TestObject{Id: 1, Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := *response.Headers
+	form := *response.Form
+
+	AssertEquals(t, headers["Content-Type"], "application/x-www-form-urlencoded")
+	AssertEquals(t, response.Method, "POST")
+	AssertEquals(t, form["id"], "1")
+	AssertEquals(t, form["name"], "foo")
+}
+
 func TestClientXml(t *testing.T) {
 	client, _ := generated.Build("my_token")
 
@@ -181,6 +198,26 @@ func TestClientXml(t *testing.T) {
 	AssertEquals(t, response.Data, "<foo>bar</foo>")
 }
 
+func TestClientMergePatch(t *testing.T) {
+	client, _ := generated.Build("my_token")
+
+	var payload = generated.TestRequestPatch{
+		String: sdkgen.Some("foobar"),
+		Object: sdkgen.Null[generated.TestObject](),
+	}
+
+	response, err := client.Product().MergePatch(1, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := *response.Headers
+
+	AssertEquals(t, headers["Content-Type"], "application/merge-patch+json")
+	AssertEquals(t, response.Method, "PATCH")
+	AssertEquals(t, response.Data, "{\"string\":\"foobar\",\"object\":null}")
+}
+
 func AssertEquals(t *testing.T, got string, want string) {
 	if got != want {
 		t.Errorf("got %q, wanted %q", got, want)
@@ -223,7 +260,7 @@ func NewPayload() generated.TestRequest {
 		String:         "foobar",
 		Bool:           true,
 		DateString:     "2024-09-22",
-		DateTimeString: "2024-09-22T10:09:00",
+		DateTimeString: "2024-09-22T10:09:00Z",
 		TimeString:     "10:09:00",
 		ArrayScalar:    arrayScalar,
 		ArrayObject:    arrayObject,
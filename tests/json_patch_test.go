@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// JsonPatchTestcase describes a test case in the format of the json-patch-tests suite
+type JsonPatchTestcase struct {
+	Comment  string          `json:"comment"`
+	Doc      json.RawMessage `json:"doc"`
+	Patch    json.RawMessage `json:"patch"`
+	Expected json.RawMessage `json:"expected"`
+	Error    string          `json:"error"`
+	Disabled bool            `json:"disabled"`
+}
+
+func TestJsonPatch(t *testing.T) {
+	files, err := filepath.Glob("testdata/jsonpatch/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("found no test data")
+	}
+
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var testcases []JsonPatchTestcase
+		err = json.Unmarshal(raw, &testcases)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for index, testcase := range testcases {
+			if testcase.Disabled {
+				continue
+			}
+
+			actual, err := ApplyJsonPatchTestcase(testcase)
+			if testcase.Error != "" {
+				if err == nil {
+					t.Errorf("%s: %d %s: expected an error (%s), got %s", file, index, testcase.Comment, testcase.Error, actual)
+				}
+			} else if err != nil {
+				t.Errorf("%s: %d %s: %s", file, index, testcase.Comment, err)
+			} else if testcase.Expected != nil {
+				AssertJsonEquals(t, actual, testcase.Expected)
+			}
+		}
+	}
+}
+
+func TestJsonPatchBuilder(t *testing.T) {
+	var patch = sdkgen.NewJsonPatch().
+		Test(sdkgen.JsonPointer("name"), "foo").
+		Replace(sdkgen.JsonPointer("name"), "bar").
+		Add(sdkgen.JsonPointer("a/b", "-"), generated.TestObject{Id: 1, Name: "foo"}).
+		Remove(sdkgen.JsonPointer("m~n")).
+		Copy("/name", "/copy").
+		Move("/copy", "/moved")
+
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), `[{"op":"test","path":"/name","value":"foo"},{"op":"replace","path":"/name","value":"bar"},{"op":"add","path":"/a~1b/-","value":{"id":1,"name":"foo"}},{"op":"remove","path":"/m~0n"},{"op":"copy","from":"/name","path":"/copy"},{"op":"move","from":"/copy","path":"/moved"}]`)
+
+	result, err := sdkgen.ApplyJsonPatch([]byte(`{"name":"foo","a/b":[],"m~n":1}`), *patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(result), `{"a/b":[{"id":1,"name":"foo"}],"moved":"bar","name":"bar"}`)
+
+	_, err = sdkgen.ApplyJsonPatch([]byte(`{"name":"bar"}`), *patch)
+	AssertEquals(t, err.Error(), "could not apply operation 0 (test /name): the value does not match")
+}
+
+func TestJsonPointer(t *testing.T) {
+	AssertEquals(t, sdkgen.JsonPointer(), "")
+	AssertEquals(t, sdkgen.JsonPointer("foo", "0", ""), "/foo/0/")
+	AssertEquals(t, sdkgen.JsonPointer("a/b", "m~n", "~1"), "/a~1b/m~0n/~01")
+
+	tokens, err := sdkgen.ParseJsonPointer("/a~1b/m~0n/~01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, tokens[0], "a/b")
+	AssertEquals(t, tokens[1], "m~n")
+	AssertEquals(t, tokens[2], "~1")
+
+	_, err = sdkgen.ParseJsonPointer("/foo~2")
+	if err == nil {
+		t.Error("expected an error for an invalid escape sequence")
+	}
+}
+
+func TestCreateJsonPatch(t *testing.T) {
+	var tests = [][3]string{
+		{`{"a":1}`, `{"a":1}`, `[]`},
+		{`{"a":1}`, `{"a":2}`, `[{"op":"replace","path":"/a","value":2}]`},
+		{`{"a":1.0}`, `{"a":1}`, `[]`},
+		{`{"a":1,"b":2}`, `{"b":2,"c":3}`, `[{"op":"remove","path":"/a"},{"op":"add","path":"/c","value":3}]`},
+		{`{"a":{"b":{"c":1}}}`, `{"a":{"b":{"c":2}}}`, `[{"op":"replace","path":"/a/b/c","value":2}]`},
+		{`{"a/b":1}`, `{"a/b":null}`, `[{"op":"replace","path":"/a~1b","value":null}]`},
+		{`[1,2,3]`, `[1,2,3,4]`, `[{"op":"add","path":"/3","value":4}]`},
+		{`[1,2,3]`, `[0,1,2,3]`, `[{"op":"add","path":"/0","value":0}]`},
+		{`[1,2,3,4]`, `[1,4]`, `[{"op":"remove","path":"/1"},{"op":"remove","path":"/1"}]`},
+		{`[1,2,3]`, `[1,5,3]`, `[{"op":"replace","path":"/1","value":5}]`},
+		{`[{"id":1},{"id":2}]`, `[{"id":1},{"id":3}]`, `[{"op":"replace","path":"/1/id","value":3}]`},
+		{`{"a":[1]}`, `{"a":{"0":1}}`, `[{"op":"replace","path":"/a","value":{"0":1}}]`},
+		{`"foo"`, `"bar"`, `[{"op":"replace","path":"","value":"bar"}]`},
+	}
+
+	for _, test := range tests {
+		patch, err := sdkgen.CreateJsonPatch(json.RawMessage(test[0]), json.RawMessage(test[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		AssertEquals(t, string(raw), test[2])
+
+		result, err := sdkgen.ApplyJsonPatch([]byte(test[0]), patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		AssertJsonEquals(t, result, []byte(test[1]))
+	}
+}
+
+func TestCreateJsonPatchModel(t *testing.T) {
+	var original = NewPayload()
+	var modified = NewPayload()
+	modified.String = "baz"
+	modified.ArrayScalar = append(modified.ArrayScalar, "baz")
+	modified.Object = &generated.TestObject{Id: 1, Name: "bar"}
+
+	patch, err := sdkgen.CreateJsonPatch(original, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), `[{"op":"add","path":"/arrayScalar/2","value":"baz"},{"op":"replace","path":"/object/name","value":"bar"},{"op":"replace","path":"/string","value":"baz"}]`)
+}
+
+func ApplyJsonPatchTestcase(testcase JsonPatchTestcase) ([]byte, error) {
+	var patch sdkgen.JsonPatch
+	err := json.Unmarshal(testcase.Patch, &patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdkgen.ApplyJsonPatch(testcase.Doc, patch)
+}
+
+func AssertJsonEquals(t *testing.T, got []byte, want []byte) {
+	var actual, expect interface{}
+	_ = json.Unmarshal(got, &actual)
+	_ = json.Unmarshal(want, &expect)
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	var links = sdkgen.ParseLinkHeader(
+		`<https://api.acme.com/items?page=2>; rel="next last"; type="application/json"; title="Page 2, the end", <https://api.acme.com/items?page=1>;rel=prev;rel=first`,
+		`</docs>; rel=describedby; title*=UTF-8'de'n%c3%a4chstes%20Kapitel; title="fallback"; version=2; deprecated, invalid; rel=self`,
+	)
+
+	AssertEquals(t, fmt.Sprint(len(links)), "3")
+
+	next, ok := links.Get("next")
+	if !ok {
+		t.Fatal("expected a next link")
+	}
+
+	AssertEquals(t, next.Href, "https://api.acme.com/items?page=2")
+	AssertEquals(t, next.Type, "application/json")
+	AssertEquals(t, next.Title, "Page 2, the end")
+	AssertEquals(t, fmt.Sprint(next.GetRels()), "[next last]")
+
+	last, _ := links.Get("LAST")
+	AssertEquals(t, last.Href, next.Href)
+
+	prev, _ := links.Get("prev")
+	AssertEquals(t, prev.Href, "https://api.acme.com/items?page=1")
+	AssertEquals(t, prev.Rel, "prev")
+
+	describedBy, _ := links.Get("describedby")
+	AssertEquals(t, describedBy.Href, "/docs")
+	AssertEquals(t, describedBy.Title, "nächstes Kapitel")
+	AssertEquals(t, describedBy.Params["version"], "2")
+
+	if _, ok := describedBy.Params["deprecated"]; !ok {
+		t.Error("expected the deprecated parameter")
+	}
+
+	if _, ok := links.Get("self"); ok {
+		t.Error("expected that the invalid link is skipped")
+	}
+}
+
+func TestParseHalLinks(t *testing.T) {
+	var document = `{"_links":{"self":{"href":"/orders/1"},"item":[{"href":"/items/1","title":"First"},{"href":"/items/2"}],"find":{"href":"/orders{?id}","templated":true}},"id":1}`
+
+	links, err := sdkgen.ParseHalLinks([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, fmt.Sprint(len(links)), "4")
+
+	items := links.GetAll("item")
+	AssertEquals(t, fmt.Sprint(len(items)), "2")
+	AssertEquals(t, items[0].Title, "First")
+	AssertEquals(t, items[1].Href, "/items/2")
+
+	find, _ := links.Get("find")
+	if !find.Templated {
+		t.Error("expected a templated link")
+	}
+}
+
+func TestFollowLink(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Link", `<items/2>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":1,"name":"%s"}`, r.URL.Path)
+	}))
+
+	defer server.Close()
+
+	client, err := sdkgen.NewClient(server.URL+"/api", sdkgen.HttpBearer{Token: "my_token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var header http.Header
+	object, err := sdkgen.FollowLink[generated.TestObject](sdkgen.WithResponseHeader(context.Background(), &header), client, sdkgen.Link{Href: "items/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "/api/items/1")
+
+	next, ok := sdkgen.GetLinks(header).Get("next")
+	if !ok {
+		t.Fatal("expected a next link")
+	}
+
+	object, err = sdkgen.FollowLink[generated.TestObject](context.Background(), client, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "/api/items/2")
+
+	object, err = sdkgen.FollowLink[generated.TestObject](context.Background(), client, sdkgen.Link{Href: "/root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "/root")
+}
+
+func TestFollowLinkCrossOrigin(t *testing.T) {
+	var authorizations []string
+	var other = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"name":"other"}`))
+	}))
+
+	defer other.Close()
+
+	client, err := sdkgen.NewClient("http://127.0.0.1:8081", sdkgen.HttpBearer{Token: "my_token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sdkgen.FollowLink[generated.TestObject](context.Background(), client, sdkgen.Link{Href: other.URL + "/items/1"})
+
+	var crossOrigin *sdkgen.CrossOriginError
+	if !errors.As(err, &crossOrigin) || len(authorizations) != 0 {
+		t.Fatalf("expected a cross origin error, got %v", err)
+	}
+
+	object, err := sdkgen.FollowLink[generated.TestObject](sdkgen.WithCrossOrigin(context.Background()), client, sdkgen.Link{Href: other.URL + "/items/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "other")
+	AssertEquals(t, authorizations[0], "Bearer my_token")
+}
+
+func TestResponseHeader(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `</anything?startIndex=10&count=10>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	defer server.Close()
+
+	client, err := generated.NewClient(server.URL, sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var header http.Header
+	_, err = client.Product().GetAllWithContext(sdkgen.WithResponseHeader(context.Background(), &header), 0, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, _ := sdkgen.GetLinks(header).Get("next")
+	AssertEquals(t, next.Href, "/anything?startIndex=10&count=10")
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type RecordingLogger struct {
+	Messages []string
+	Fields   []map[string]interface{}
+}
+
+func (logger *RecordingLogger) Log(message string, fields []sdkgen.LogField) {
+	var values = make(map[string]interface{})
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+
+	logger.Messages = append(logger.Messages, message)
+	logger.Fields = append(logger.Fields, values)
+}
+
+func TestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"method":        r.Method,
+			"data":          "foobar",
+			"access_token":  "secret",
+			"refresh_token": "secret",
+		})
+	}))
+	defer server.Close()
+
+	var logger = &RecordingLogger{}
+	var logging = sdkgen.NewLogging(logger)
+	logging.Headers = true
+	logging.Body = true
+	logging.RedactFields = []string{"string"}
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.ApiKey{Name: "X-Secret", Token: "my_token"}, sdkgen.ClientOptions{
+		Logging: logging,
+	})
+
+	response, err := client.Product().Create(NewPayload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Method, "POST")
+	AssertEquals(t, response.Data, "foobar")
+
+	if len(logger.Fields) != 1 {
+		t.Fatalf("expected one log entry, got %d", len(logger.Fields))
+	}
+
+	var fields = logger.Fields[0]
+
+	AssertEquals(t, logger.Messages[0], "sdkgen request")
+	AssertEquals(t, fields["method"].(string), "POST")
+	AssertEquals(t, fields["url"].(string), server.URL+"/anything")
+
+	if fields["status"] != 200 || fields["attempt"] != 1 {
+		t.Errorf("got unexpected status %v or attempt %v", fields["status"], fields["attempt"])
+	}
+
+	for _, key := range []string{"request_headers", "response_headers", "request_body", "response_body"} {
+		var value = fields[key].(string)
+		if strings.Contains(value, "secret") || strings.Contains(value, "my_token") || strings.Contains(value, "foobar\",\"bool") {
+			t.Errorf("found a secret at %s: %s", key, value)
+		}
+
+		if !strings.Contains(value, "[REDACTED]") {
+			t.Errorf("expected a redacted value at %s: %s", key, value)
+		}
+	}
+}
+
+func TestLoggingMaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generated.TestResponse{Data: strings.Repeat("a", 64)})
+	}))
+	defer server.Close()
+
+	var logger = &RecordingLogger{}
+	var logging = sdkgen.NewLogging(logger)
+	logging.Body = true
+	logging.MaxBodySize = 16
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Logging: logging,
+	})
+
+	response, err := client.Product().Text(strings.Repeat("b", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Data, strings.Repeat("a", 64))
+	AssertEquals(t, logger.Fields[0]["request_body"].(string), strings.Repeat("b", 16)+"...(truncated)")
+	AssertEquals(t, logger.Fields[0]["response_body"].(string), "[REDACTED]...(truncated)")
+}
+
+func TestLoggingStream(t *testing.T) {
+	var release = make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte("{\"id\":1}\n"))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("{\"id\":2}\n"))
+	}))
+	defer server.Close()
+
+	var logger = &RecordingLogger{}
+	var logging = sdkgen.NewLogging(logger)
+	logging.Body = true
+
+	client, err := sdkgen.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Logging: logging,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.HttpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, line, "{\"id\":1}\n")
+
+	if len(logger.Fields) != 0 {
+		t.Fatalf("expected that the entry is logged after the body was read, got %d", len(logger.Fields))
+	}
+
+	close(release)
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if len(logger.Fields) != 1 {
+		t.Fatalf("expected one log entry, got %d", len(logger.Fields))
+	}
+
+	if _, ok := logger.Fields[0]["response_size"]; ok {
+		t.Errorf("expected no response size for a body of unknown length")
+	}
+
+	AssertEquals(t, logger.Fields[0]["response_body"].(string), "{\"id\":1}\n{\"id\":2}\n")
+}
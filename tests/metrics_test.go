@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "DELETE" {
+			w.WriteHeader(404)
+		}
+
+		json.NewEncoder(w).Encode(generated.TestResponse{Method: r.Method})
+	}))
+	defer server.Close()
+
+	var metrics = sdkgen.NewMemoryMetrics()
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Metrics: metrics,
+	})
+
+	_, err := client.Product().GetAll(0, 16, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Product().GetAll(16, 16, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Product().Delete(1)
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+
+	var getAll = sdkgen.Operation{Tag: "ProductTag", Name: "GetAll"}
+	var remove = sdkgen.Operation{Tag: "ProductTag", Name: "Delete"}
+
+	if metrics.GetRequestCount(getAll) != 2 || metrics.GetRequestCount(remove) != 1 {
+		t.Errorf("got unexpected request counts %d and %d", metrics.GetRequestCount(getAll), metrics.GetRequestCount(remove))
+	}
+
+	if metrics.GetErrorCount(getAll, "4xx") != 0 || metrics.GetErrorCount(remove, "4xx") != 1 {
+		t.Errorf("got unexpected error counts %d and %d", metrics.GetErrorCount(getAll, "4xx"), metrics.GetErrorCount(remove, "4xx"))
+	}
+
+	var buffer bytes.Buffer
+	err = metrics.WritePrometheus(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expects = []string{
+		"sdkgen_requests_total{tag=\"ProductTag\",operation=\"ProductTag.GetAll\"} 2\n",
+		"sdkgen_requests_total{tag=\"ProductTag\",operation=\"ProductTag.Delete\"} 1\n",
+		"sdkgen_request_errors_total{tag=\"ProductTag\",operation=\"ProductTag.Delete\",status_class=\"4xx\"} 1\n",
+		"sdkgen_request_duration_seconds_bucket{tag=\"ProductTag\",operation=\"ProductTag.GetAll\",le=\"+Inf\"} 2\n",
+		"sdkgen_request_duration_seconds_count{tag=\"ProductTag\",operation=\"ProductTag.GetAll\"} 2\n",
+	}
+
+	for _, expect := range expects {
+		if !strings.Contains(buffer.String(), expect) {
+			t.Errorf("expected %q in exposition:\n%s", expect, buffer.String())
+		}
+	}
+}
+
+func TestMetricsTokenRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			json.NewEncoder(w).Encode(sdkgen.AccessToken{TokenType: "bearer", AccessToken: "my_token", ExpiresIn: 3600})
+			return
+		}
+
+		json.NewEncoder(w).Encode(generated.TestResponse{Method: r.Method})
+	}))
+	defer server.Close()
+
+	var metrics = sdkgen.NewMemoryMetrics()
+	var credentials = sdkgen.OAuth2{
+		ClientId:     "foo",
+		ClientSecret: "bar",
+		TokenUrl:     server.URL + "/token",
+		TokenStore:   sdkgen.NewMemoryTokenStore(),
+	}
+
+	client, _ := generated.NewClientWithOptions(server.URL, credentials, sdkgen.ClientOptions{
+		Metrics: metrics,
+	})
+
+	_, err := client.Product().Delete(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metrics.GetTokenRefreshCount("client_credentials") != 1 {
+		t.Errorf("expected one token refresh, got %d", metrics.GetTokenRefreshCount("client_credentials"))
+	}
+}
+
+func TestMetricsTokenRefreshFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" && r.FormValue("grant_type") == "authorization_code" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(sdkgen.AccessToken{TokenType: "bearer", AccessToken: "my_token", ExpiresIn: 3600})
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var metrics = sdkgen.NewMemoryMetrics()
+	var authenticator = &sdkgen.OAuth2Authenticator{
+		Credentials: sdkgen.OAuth2{
+			ClientId:     "foo",
+			ClientSecret: "bar",
+			TokenUrl:     server.URL + "/token",
+			TokenStore:   sdkgen.NewMemoryTokenStore(),
+		},
+		Metrics: metrics,
+	}
+
+	_, err := authenticator.FetchAccessTokenByClientCredentials()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	_, err = authenticator.FetchAccessTokenByCode("my_code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metrics.GetTokenRefreshCount("client_credentials") != 0 || metrics.GetTokenRefreshCount("authorization_code") != 0 {
+		t.Errorf("expected no token refresh, got %d", metrics.GetTokenRefreshCount("client_credentials"))
+	}
+}
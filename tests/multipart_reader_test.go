@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMultipartReader(t *testing.T) {
+	var payload = sdkgen.NewMultipart("mixed", nil)
+	_ = payload.AddJson("first", generated.TestObject{Id: 1, Name: "foo"})
+	_ = payload.AddJson("second", generated.TestObject{Id: 2, Name: "bar"})
+	payload.AddFile("file", "upload.txt", strings.NewReader("foobar"))
+
+	reader, err := sdkgen.NewMultipartReader(payload.GetContentType(), payload.Stream())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer reader.Close()
+
+	var objects []generated.TestObject
+	var files []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if part.IsJson() {
+			object, err := sdkgen.DecodePart[generated.TestObject](part)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			objects = append(objects, object)
+		} else {
+			raw, _ := io.ReadAll(part.Body)
+			files = append(files, part.FileName+":"+part.ContentType+":"+string(raw))
+		}
+	}
+
+	if len(objects) != 2 || len(files) != 1 {
+		t.Fatalf("expected two objects and one file, got %d and %d", len(objects), len(files))
+	}
+
+	AssertEquals(t, objects[0].Name, "foo")
+	AssertEquals(t, objects[1].Name, "bar")
+	AssertEquals(t, files[0], "upload.txt:application/octet-stream:foobar")
+}
+
+func TestMultipartReaderInvalidContentType(t *testing.T) {
+	_, err := sdkgen.NewMultipartReader("application/json", strings.NewReader("{}"))
+	if err == nil {
+		t.Error("expected an error for a non multipart content type")
+	}
+}
+
+func TestClientMultipartException(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload = &sdkgen.Multipart{}
+		payload.AddField("error", strings.NewReader("foobar"))
+
+		w.Header().Set("Content-Type", payload.GetContentType())
+		w.WriteHeader(500)
+		w.Write(payload.Build().Bytes())
+	}))
+	defer server.Close()
+
+	client, _ := generated.NewClient(server.URL, sdkgen.Anonymous{})
+
+	_, err := client.Product().Multipart(&sdkgen.Multipart{})
+
+	var exception *generated.MultipartException
+	if !errors.As(err, &exception) || exception.Previous != nil {
+		t.Fatalf("expected a multipart exception, got %v", err)
+	}
+
+	var fields = exception.Payload.GetFields()
+	if len(fields) != 1 {
+		t.Fatalf("expected one field, got %d", len(fields))
+	}
+
+	raw, _ := io.ReadAll(fields[0].Reader)
+
+	AssertEquals(t, fields[0].Name, "error")
+	AssertEquals(t, string(raw), "foobar")
+}
@@ -0,0 +1,266 @@
+package tests
+
+import (
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"testing/iotest"
+)
+
+func TestMultipartStream(t *testing.T) {
+	var content = strings.Repeat("foobar", 1024*64)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(1024)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		raw, _ := io.ReadAll(file)
+		w.Write([]byte(strconv.FormatInt(r.ContentLength, 10) + ":" + strconv.Itoa(len(raw)) + ":" + r.FormValue("foo")))
+	}))
+	defer server.Close()
+
+	var opened = 0
+	var payload = &sdkgen.Multipart{}
+	payload.AddFileSource("file", "upload.txt", int64(len(content)), func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader(content)), nil
+	})
+	payload.AddField("foo", strings.NewReader("bar"))
+
+	req, _ := http.NewRequest("POST", server.URL, payload.Stream())
+	req.ContentLength = payload.GetContentLength()
+	req.GetBody = payload.GetBody()
+	req.Header.Set("Content-Type", payload.GetContentType())
+
+	if req.ContentLength <= int64(len(content)) || req.GetBody == nil {
+		t.Fatalf("expected a known content length and a reopenable body, got %d", req.ContentLength)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+
+	AssertEquals(t, string(raw), strconv.FormatInt(req.ContentLength, 10)+":"+strconv.Itoa(len(content))+":bar")
+
+	body, _ := req.GetBody()
+	retry, _ := io.ReadAll(body)
+	if int64(len(retry)) != req.ContentLength || opened != 2 {
+		t.Errorf("expected a reopened body with %d bytes, got %d bytes", req.ContentLength, len(retry))
+	}
+}
+
+func TestMultipartStreamError(t *testing.T) {
+	var payload = &sdkgen.Multipart{}
+	payload.AddFileSource("file", "upload.txt", -1, func() (io.ReadCloser, error) {
+		return nil, errors.New("could not open file")
+	})
+
+	if payload.GetContentLength() != -1 {
+		t.Errorf("expected an unknown content length, got %d", payload.GetContentLength())
+	}
+
+	_, err := io.ReadAll(payload.Stream())
+	if err == nil || err.Error() != "could not open file" {
+		t.Errorf("expected the open error, got %v", err)
+	}
+}
+
+func TestMultipartBuildError(t *testing.T) {
+	var payload = &sdkgen.Multipart{}
+	payload.AddField("name", strings.NewReader("foo"))
+	payload.AddFile("file", "upload.txt", iotest.ErrReader(errors.New("could not read file")))
+
+	_, err := payload.BuildWithError()
+	if err == nil || err.Error() != "could not read file" {
+		t.Errorf("expected the read error, got %v", err)
+	}
+}
+
+func TestMultipartGetBody(t *testing.T) {
+	var payload = &sdkgen.Multipart{}
+	payload.AddFile("file", "upload.txt", io.MultiReader(strings.NewReader("foobar")))
+
+	if payload.GetBody() != nil {
+		t.Error("expected no body function for a reader which can not be reopened")
+	}
+}
+
+func TestMultipartGetBodyReopen(t *testing.T) {
+	var content = strings.Repeat("foobar", 16*1024)
+	var payload = &sdkgen.Multipart{}
+	payload.AddField("name", strings.NewReader("foo"))
+	payload.AddFile("file", "upload.txt", strings.NewReader(content))
+
+	var expect = payload.Build().String()
+
+	var first = payload.Stream()
+	_, err := io.ReadFull(first, make([]byte, 512))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := payload.GetBody()()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = io.ReadAll(first)
+	if err == nil {
+		t.Error("expected that the previous stream was closed")
+	}
+
+	body, err := io.ReadAll(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(body), expect)
+}
+
+func TestMultipartUnknownSize(t *testing.T) {
+	var payload = &sdkgen.Multipart{}
+	payload.AddFilePart(sdkgen.FilePart{
+		Name:     "file",
+		FileName: "upload.txt",
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("foobar")), nil
+		},
+	})
+
+	if payload.GetContentLength() != -1 {
+		t.Errorf("expected an unknown content length, got %d", payload.GetContentLength())
+	}
+
+	payload = &sdkgen.Multipart{}
+	payload.AddFieldPart(sdkgen.FieldPart{Name: "empty", SizeKnown: true})
+	payload.AddField("name", strings.NewReader("foo"))
+
+	if payload.GetContentLength() != int64(len(payload.Build().Bytes())) {
+		t.Errorf("expected a content length of %d, got %d", len(payload.Build().Bytes()), payload.GetContentLength())
+	}
+}
+
+func TestMultipartParts(t *testing.T) {
+	var png = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	var fsys = fstest.MapFS{
+		"images/logo": &fstest.MapFile{Data: png},
+	}
+
+	var payload = &sdkgen.Multipart{}
+	err := payload.AddJson("metadata", generated.TestObject{Id: 1, Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = payload.AddFileFS("image", fsys, "images/logo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload.AddFieldPart(sdkgen.FieldPart{
+		Name:        "note",
+		ContentType: "text/plain; charset=utf-8",
+		Header:      textproto.MIMEHeader{"X-Note": []string{"foo"}},
+		Reader:      strings.NewReader("bar"),
+	})
+
+	var parts = ReadParts(t, payload)
+	if len(parts) != 3 {
+		t.Fatalf("expected three parts, got %d", len(parts))
+	}
+
+	AssertEquals(t, parts[0].FormName(), "image")
+	AssertEquals(t, parts[0].FileName(), "logo")
+	AssertEquals(t, parts[0].Header.Get("Content-Type"), "image/png")
+	AssertEquals(t, parts[0].Body, string(png))
+	AssertEquals(t, parts[1].FormName(), "metadata")
+	AssertEquals(t, parts[1].Header.Get("Content-Type"), "application/json")
+	AssertEquals(t, parts[1].Body, "{\"id\":1,\"name\":\"foo\"}")
+	AssertEquals(t, parts[2].Header.Get("Content-Type"), "text/plain; charset=utf-8")
+	AssertEquals(t, parts[2].Header.Get("X-Note"), "foo")
+
+	if payload.GetContentLength() != int64(len(payload.Build().Bytes())) {
+		t.Errorf("expected a content length of %d, got %d", len(payload.Build().Bytes()), payload.GetContentLength())
+	}
+}
+
+func TestMultipartRelated(t *testing.T) {
+	var payload = sdkgen.NewMultipart("related", map[string]string{"type": "application/json", "start": "<root>"})
+	payload.AddFieldPart(sdkgen.FieldPart{
+		ContentType: "application/json",
+		Header:      textproto.MIMEHeader{"Content-ID": []string{"<root>"}},
+		Reader:      strings.NewReader("{}"),
+	})
+	payload.AddFilePart(sdkgen.FilePart{
+		FileName:    "upload.txt",
+		ContentType: "text/plain",
+		Reader:      strings.NewReader("foobar"),
+	})
+
+	mediaType, params, _ := mime.ParseMediaType(payload.GetContentType())
+
+	AssertEquals(t, mediaType, "multipart/related")
+	AssertEquals(t, params["type"], "application/json")
+	AssertEquals(t, params["start"], "<root>")
+
+	var parts = ReadParts(t, payload)
+	if len(parts) != 2 {
+		t.Fatalf("expected two parts, got %d", len(parts))
+	}
+
+	AssertEquals(t, parts[0].Header.Get("Content-ID"), "<root>")
+	AssertEquals(t, parts[0].Header.Get("Content-Disposition"), "")
+	AssertEquals(t, parts[1].Header.Get("Content-Disposition"), "attachment; filename=\"upload.txt\"")
+	AssertEquals(t, parts[1].Body, "foobar")
+}
+
+type ReadPart struct {
+	*multipart.Part
+	Body string
+}
+
+func ReadParts(t *testing.T, payload *sdkgen.Multipart) []ReadPart {
+	_, params, err := mime.ParseMediaType(payload.GetContentType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []ReadPart
+	var reader = multipart.NewReader(payload.Stream(), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, _ := io.ReadAll(part)
+		result = append(result, ReadPart{Part: part, Body: string(raw)})
+	}
+
+	return result
+}
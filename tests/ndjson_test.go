@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNdjsonIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", sdkgen.NdjsonContentType)
+		w.Write([]byte("{\"id\":1,\"name\":\"foo\"}\n\n{\"id\":2,\"name\":\"bar\"}\r\n{\"id\":3,\"name\":\"baz\"}"))
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+
+	resp, err := client.HttpClient.Get(server.URL + "/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	var iterator = sdkgen.NewNdjsonIterator[generated.TestObject](resp.Body)
+	defer iterator.Close()
+
+	for iterator.Next() {
+		names = append(names, iterator.Item().Name)
+	}
+
+	if iterator.Err() != nil {
+		t.Fatal(iterator.Err())
+	}
+
+	AssertEquals(t, strings.Join(names, ","), "foo,bar,baz")
+}
+
+func TestNdjsonIteratorLineError(t *testing.T) {
+	var iterator = sdkgen.NewNdjsonIterator[generated.TestObject](strings.NewReader("{\"id\":1}\n{\"id\":\"foo\"}\n{\"id\":3}\n"))
+
+	var count = 0
+	for iterator.Next() {
+		count++
+	}
+
+	var lineError *sdkgen.NdjsonLineError
+	if count != 1 || !errors.As(iterator.Err(), &lineError) || lineError.Line != 2 {
+		t.Errorf("expected an error at line 2 after one item, got %d items and %v", count, iterator.Err())
+	}
+
+	iterator = sdkgen.NewNdjsonIterator[generated.TestObject](strings.NewReader("{\"id\":1}\n{\"id\":\"foo\"}\n{\"id\":3}\n"))
+	iterator.SkipInvalid = true
+
+	count = 0
+	for iterator.Next() {
+		count++
+	}
+
+	if count != 2 || iterator.Err() != nil || len(iterator.Errors()) != 1 {
+		t.Errorf("expected two items and one skipped line, got %d items and %v", count, iterator.Errors())
+	}
+}
+
+func TestNdjsonIteratorClose(t *testing.T) {
+	var iterator = sdkgen.NewNdjsonIterator[generated.TestObject](io.NopCloser(strings.NewReader("{\"id\":1}\n{\"id\":2}\n")))
+
+	if !iterator.Next() || iterator.Close() != nil || iterator.Next() {
+		t.Error("expected no further items after close")
+	}
+}
+
+func TestNdjsonBody(t *testing.T) {
+	var channel = make(chan generated.TestObject)
+	go func() {
+		channel <- generated.TestObject{Id: 1, Name: "foo"}
+		channel <- generated.TestObject{Id: 2, Name: "bar"}
+		close(channel)
+	}()
+
+	raw, err := io.ReadAll(sdkgen.NewNdjsonChannelBody(channel))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), "{\"id\":1,\"name\":\"foo\"}\n{\"id\":2,\"name\":\"bar\"}\n")
+
+	var iterator = sdkgen.NewNdjsonIterator[generated.TestObject](strings.NewReader(string(raw)))
+
+	raw, err = io.ReadAll(sdkgen.NewNdjsonBody[generated.TestObject](iterator))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), "{\"id\":1,\"name\":\"foo\"}\n{\"id\":2,\"name\":\"bar\"}\n")
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOperation(t *testing.T) {
+	var operations []sdkgen.Operation
+	var transport = sdkgen.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		operation, ok := sdkgen.GetOperation(req.Context())
+		if !ok {
+			t.Errorf("found no operation at request %s", req.URL)
+		}
+
+		operations = append(operations, operation)
+
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    req,
+		}, nil
+	})
+
+	client, _ := generated.NewClientWithOptions("http://127.0.0.1", sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Transport: transport,
+	})
+
+	_, _ = client.Product().Update(1, NewPayload())
+	_, _ = client.Product().Xml("<foo>bar</foo>")
+
+	if len(operations) != 2 {
+		t.Fatalf("expected two operations, got %d", len(operations))
+	}
+
+	AssertEquals(t, operations[0].Id, "product.update")
+	AssertEquals(t, operations[0].GetFullName(), "ProductTag.Update")
+	AssertEquals(t, operations[0].Method, "PUT")
+	AssertEquals(t, operations[0].Path, "/anything/:id")
+	AssertEquals(t, strings.Join(operations[0].Security, ","), "bearer")
+	AssertEquals(t, operations[1].GetFullName(), "ProductTag.Xml")
+
+	if operations[0].IsExpectedStatusCode(500) || !operations[1].IsExpectedStatusCode(500) || !operations[1].IsExpectedStatusCode(204) {
+		t.Errorf("got unexpected status codes %v and %v", operations[0].StatusCodes, operations[1].StatusCodes)
+	}
+}
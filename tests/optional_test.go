@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"reflect"
+	"testing"
+)
+
+type ProductPatch struct {
+	Name   *sdkgen.Nullable[string]               `json:"name,omitempty"`
+	Price  *sdkgen.Nullable[float64]              `json:"price,omitempty"`
+	Tags   *sdkgen.Nullable[[]string]             `json:"tags,omitempty"`
+	Object *sdkgen.Nullable[generated.TestObject] `json:"object,omitempty"`
+}
+
+func TestOptionalMarshal(t *testing.T) {
+	var patch = ProductPatch{
+		Name: sdkgen.Some("foo"),
+		Tags: sdkgen.Null[[]string](),
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(data), `{"name":"foo","tags":null}`)
+
+	data, err = json.Marshal(ProductPatch{Price: sdkgen.Some(0.0), Object: sdkgen.Null[generated.TestObject]()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(data), `{"price":0,"object":null}`)
+}
+
+func TestOptionalUnmarshal(t *testing.T) {
+	var patch ProductPatch
+	err := json.Unmarshal([]byte(`{"name":"foo","object":{"id":1,"name":"bar"}}`), &patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, ok := patch.Name.Get()
+	if !ok {
+		t.Error("expected a name")
+	}
+
+	AssertEquals(t, name, "foo")
+
+	if patch.Price != nil {
+		t.Error("expected that the price is absent")
+	}
+
+	AssertEquals(t, patch.Object.GetOrDefault(generated.TestObject{}).Name, "bar")
+}
+
+func TestOptionalUnmarshalPatch(t *testing.T) {
+	var patch generated.TestRequestPatch
+	err := json.Unmarshal([]byte(`{"string":"foo","object":null,"int":0}`), &patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, patch.String.GetOrDefault(""), "foo")
+
+	if patch.Object == nil || !patch.Object.IsNull() {
+		t.Error("expected an explicit null object")
+	}
+
+	if patch.Int == nil || patch.Int.IsNull() {
+		t.Error("expected an int value")
+	}
+
+	if patch.Float != nil || patch.ArrayScalar != nil {
+		t.Error("expected that absent fields are nil")
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(data), `{"int":0,"string":"foo","object":null}`)
+}
+
+func TestNullable(t *testing.T) {
+	var values []sdkgen.Nullable[int]
+	err := json.Unmarshal([]byte(`[1,null,0]`), &values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !values[0].Valid || values[1].Valid || !values[2].Valid {
+		t.Errorf("got unexpected values %v", values)
+	}
+
+	data, err := json.Marshal([]sdkgen.Nullable[string]{sdkgen.NewNullable("foo"), {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(data), `["foo",null]`)
+}
+
+func TestMergePatch(t *testing.T) {
+	// test cases from appendix A of RFC 7396
+	var tests = [][3]string{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range tests {
+		result, err := sdkgen.ApplyMergePatch([]byte(test[0]), []byte(test[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		AssertEquals(t, string(result), test[2])
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	var original = `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged","price":10.00}`
+	var modified = `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"This will be unchanged","phoneNumber":"+01-123-456-7890","price":10.00}`
+
+	patch, err := sdkgen.CreateMergePatch([]byte(original), []byte(modified))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(patch), `{"author":{"familyName":null},"phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`)
+
+	result, err := sdkgen.ApplyMergePatch([]byte(original), patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual, expect interface{}
+	_ = json.Unmarshal(result, &actual)
+	_ = json.Unmarshal([]byte(modified), &expect)
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("got %s, wanted %s", result, modified)
+	}
+}
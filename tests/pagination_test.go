@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOffsetPaginator(t *testing.T) {
+	var collection = NewCollection(7)
+	var requests []string
+
+	var paginator = sdkgen.NewOffsetPaginator[generated.TestObject](context.Background(), 3, func(ctx context.Context, startIndex int, count int) ([]generated.TestObject, error) {
+		requests = append(requests, fmt.Sprintf("%d-%d", startIndex, count))
+		return Slice(collection, startIndex, count), nil
+	})
+
+	items, err := paginator.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, strconv.Itoa(len(items)), "7")
+	AssertEquals(t, items[6].Name, "item-7")
+	AssertEquals(t, fmt.Sprint(requests), "[0-3 3-3 6-3]")
+}
+
+func TestPagePaginatorMaxItems(t *testing.T) {
+	var collection = NewCollection(10)
+	var requests = 0
+
+	var paginator = sdkgen.NewPagePaginator[generated.TestObject](context.Background(), 1, 2, func(ctx context.Context, page int, pageSize int) ([]generated.TestObject, error) {
+		requests++
+		return Slice(collection, (page-1)*pageSize, pageSize), nil
+	})
+
+	paginator.MaxItems = 3
+
+	items, err := paginator.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, strconv.Itoa(len(items)), "3")
+	AssertEquals(t, strconv.Itoa(requests), "2")
+}
+
+func TestPagePaginatorPrefetchMaxItems(t *testing.T) {
+	var collection = NewCollection(10)
+	var requests int32
+
+	var paginator = sdkgen.NewPagePaginator[generated.TestObject](context.Background(), 1, 2, func(ctx context.Context, page int, pageSize int) ([]generated.TestObject, error) {
+		atomic.AddInt32(&requests, 1)
+		return Slice(collection, (page-1)*pageSize, pageSize), nil
+	})
+
+	paginator.Prefetch = true
+	paginator.MaxItems = 4
+
+	items, err := paginator.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wait a moment so that an unexpected prefetch request would be counted
+	time.Sleep(50 * time.Millisecond)
+
+	AssertEquals(t, strconv.Itoa(len(items)), "4")
+	AssertEquals(t, strconv.Itoa(int(atomic.LoadInt32(&requests))), "2")
+}
+
+func TestCursorPaginatorPrefetch(t *testing.T) {
+	var collection = NewCollection(5)
+	var fetched = make(chan string, 10)
+
+	var paginator = sdkgen.NewCursorPaginator[generated.TestObject](context.Background(), func(ctx context.Context, cursor string) ([]generated.TestObject, string, error) {
+		fetched <- cursor
+
+		var startIndex = 0
+		if cursor != "" {
+			startIndex, _ = strconv.Atoi(cursor)
+		}
+
+		var items = Slice(collection, startIndex, 2)
+		if startIndex+2 >= len(collection) {
+			return items, "", nil
+		}
+
+		return items, strconv.Itoa(startIndex + 2), nil
+	})
+
+	paginator.Prefetch = true
+
+	if !paginator.Next() {
+		t.Fatal(paginator.Err())
+	}
+
+	// the second page is requested in the background while the first page is consumed
+	AssertEquals(t, <-fetched, "")
+	AssertEquals(t, <-fetched, "2")
+
+	var names []string
+	names = append(names, paginator.Item().Name)
+	for paginator.Next() {
+		names = append(names, paginator.Item().Name)
+	}
+
+	if paginator.Err() != nil {
+		t.Fatal(paginator.Err())
+	}
+
+	AssertEquals(t, fmt.Sprint(names), "[item-1 item-2 item-3 item-4 item-5]")
+}
+
+func TestPaginatorContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var paginator = sdkgen.NewPagePaginator[generated.TestObject](ctx, 1, 2, func(ctx context.Context, page int, pageSize int) ([]generated.TestObject, error) {
+		return NewCollection(2), nil
+	})
+
+	paginator.Next()
+	paginator.Next()
+	cancel()
+
+	if paginator.Next() {
+		t.Fatal("expected no further item")
+	}
+
+	if !errors.Is(paginator.Err(), context.Canceled) {
+		t.Errorf("expected a canceled error, got %v", paginator.Err())
+	}
+}
+
+func TestLinkPaginator(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", `</items?page=`+strconv.Itoa(page+1)+`>; rel="next", </items?page=0>; rel="first"`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{"id":%d,"name":"page-%d"}]`, page, page)
+	}))
+
+	defer server.Close()
+
+	client, err := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paginator = sdkgen.NewLinkPaginator[generated.TestObject](context.Background(), client.HttpClient, server.URL+"/items", func(resp *http.Response) ([]generated.TestObject, error) {
+		return sdkgen.DecodeJsonResponse[[]generated.TestObject](resp)
+	})
+
+	items, err := paginator.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, fmt.Sprint(items), "[{0 page-0} {1 page-1} {2 page-2}]")
+}
+
+func TestLinkPaginatorCrossOrigin(t *testing.T) {
+	var other = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected that the other origin is not requested")
+	}))
+
+	defer other.Close()
+
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+other.URL+`/items?page=1>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":0,"name":"page-0"}]`))
+	}))
+
+	defer server.Close()
+
+	client, err := sdkgen.NewClient(server.URL, sdkgen.HttpBearer{Token: "my_token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paginator = sdkgen.NewLinkPaginator[generated.TestObject](context.Background(), client.HttpClient, server.URL+"/items", func(resp *http.Response) ([]generated.TestObject, error) {
+		return sdkgen.DecodeJsonResponse[[]generated.TestObject](resp)
+	})
+
+	items, err := paginator.All()
+
+	if len(items) != 1 || items[0].Name != "page-0" {
+		t.Errorf("expected the items of the last valid page, got %v", items)
+	}
+
+	var crossOrigin *sdkgen.CrossOriginError
+	if !errors.As(err, &crossOrigin) {
+		t.Errorf("expected a cross origin error, got %v", err)
+	}
+}
+
+func NewCollection(size int) []generated.TestObject {
+	var collection []generated.TestObject
+	for i := 1; i <= size; i++ {
+		collection = append(collection, generated.TestObject{Id: i, Name: "item-" + strconv.Itoa(i)})
+	}
+
+	return collection
+}
+
+func Slice(collection []generated.TestObject, startIndex int, count int) []generated.TestObject {
+	if startIndex >= len(collection) {
+		return nil
+	}
+
+	var end = startIndex + count
+	if end > len(collection) {
+		end = len(collection)
+	}
+
+	return collection[startIndex:end]
+}
@@ -1,8 +1,11 @@
 package tests
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/apioo/sdkgen-go/v2"
 	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net"
 	"testing"
 	"time"
 )
@@ -34,13 +37,74 @@ func TestUrl(t *testing.T) {
 	tests = append(tests, Entry{Path: "/foo/:bar", Parameters: Map("bar", false), Expect: "https://api.acme.com/foo/0"})
 	tests = append(tests, Entry{Path: "/foo/:bar", Parameters: Map("bar", "foo"), Expect: "https://api.acme.com/foo/foo"})
 
+	tests = append(tests, Entry{Path: "/foo/:bar", Parameters: Map("bar", "a/b?c"), Expect: "https://api.acme.com/foo/a%2Fb%3Fc"})
+	tests = append(tests, Entry{Path: "/foo/{bar}", Parameters: Map("bar", "a/b?c"), Expect: "https://api.acme.com/foo/a%2Fb%3Fc"})
+	tests = append(tests, Entry{Path: "/foo/{bar}.json", Parameters: Map("bar", "hello world"), Expect: "https://api.acme.com/foo/hello%20world.json"})
+
+	tests = append(tests, Entry{Path: "/foo/{bar}/baz", Parameters: nil, Expect: "https://api.acme.com/foo/{bar}/baz"})
+
 	for _, test := range tests {
 		got := parser.Url(test.Path, test.Parameters)
 		want := test.Expect
 		if got != test.Expect {
 			t.Errorf("got %q, wanted %q", got, want)
 		}
+
+		got, err := parser.UrlWithError(test.Path, test.Parameters)
+		if err != nil {
+			t.Errorf("got error %s, wanted %q", err, want)
+		} else if got != test.Expect {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	}
+}
+
+func TestUrlUnsupportedValue(t *testing.T) {
+	var parser = sdkgen.NewParser("https://api.acme.com/")
+
+	_, err := parser.UrlWithError("/foo/:bar", Map("bar", generated.TestObject{}))
+
+	var unsupported *sdkgen.UnsupportedValueError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("expected an unsupported value error, got %v", err)
+	}
+}
+
+func TestQueryUnsupportedValue(t *testing.T) {
+	var parser = sdkgen.NewParser("https://api.acme.com/")
+
+	_, err := parser.QueryWithStyle(Map("bar", func() {}), []string{}, nil)
+
+	var unsupported *sdkgen.UnsupportedValueError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("expected an unsupported value error, got %v", err)
+	}
+}
+
+func TestUrlInvalidTemplate(t *testing.T) {
+	var parser = sdkgen.NewParser("https://api.acme.com/")
+
+	_, err := parser.UrlWithError("/foo/{bar", Map("bar", "foo"))
+	if err == nil {
+		t.Error("expected an error for an invalid template segment")
 	}
+
+	AssertEquals(t, parser.Url("/foo/{bar/baz", Map("bar", "foo")), "https://api.acme.com/foo/{bar/baz")
+}
+
+func TestUrlTemplate(t *testing.T) {
+	var parser = sdkgen.NewParser("https://api.acme.com/")
+
+	var parameters = make(map[string]interface{})
+	parameters["path"] = []string{"foo", "bar baz"}
+	parameters["q"] = "a&b"
+
+	got, err := parser.UrlTemplate("/files{/path*}{?q}", parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, got, "https://api.acme.com/files/foo/bar%20baz?q=a%26b")
 }
 
 func TestQuery(t *testing.T) {
@@ -57,12 +121,13 @@ func TestQuery(t *testing.T) {
 	parameters["true"] = true
 	parameters["false"] = false
 	parameters["string"] = "foo"
-	parameters["date"] = time.Date(2023, 2, 21, 0, 0, 0, 0, time.UTC)
+	parameters["date"] = sdkgen.Date{Time: time.Date(2023, 2, 21, 0, 0, 0, 0, time.UTC)}
 	parameters["datetime"] = time.Date(2023, 2, 21, 19, 19, 0, 0, time.UTC)
-	parameters["time"] = time.Date(1970, 1, 1, 19, 19, 0, 0, time.UTC)
+	parameters["time"] = sdkgen.Time{Time: time.Date(1970, 1, 1, 19, 19, 0, 0, time.UTC)}
+	parameters["midnight"] = time.Date(2023, 2, 21, 0, 0, 0, 0, time.UTC)
 	parameters["args"] = test
 
-	var result = parser.QueryWithStruct(parameters, []string{"args"})
+	result := parser.QueryWithStruct(parameters, []string{"args"})
 
 	AssertEquals(t, result.Get("int"), "1337")
 	AssertEquals(t, result.Get("float"), "13.37")
@@ -72,9 +137,152 @@ func TestQuery(t *testing.T) {
 	AssertEquals(t, result.Get("date"), "2023-02-21")
 	AssertEquals(t, result.Get("datetime"), "2023-02-21T19:19:00Z")
 	AssertEquals(t, result.Get("time"), "19:19:00")
+	AssertEquals(t, result.Get("midnight"), "2023-02-21T00:00:00Z")
 	AssertEquals(t, result.Get("name"), "foo")
 }
 
+func TestQueryWithStyle(t *testing.T) {
+	var parser = sdkgen.NewParser("https://api.acme.com/")
+
+	var filter = map[string]interface{}{
+		"name": "foo",
+		"tags": []string{"a", "b"},
+		"range": map[string]interface{}{
+			"from": 1,
+			"to":   10,
+		},
+	}
+
+	var tests []QueryEntry
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleForm, Explode: true}, Value: []int{3, 4, 5}, Expect: "id=3&id=4&id=5"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleForm}, Value: []int{3, 4, 5}, Expect: "id=3%2C4%2C5"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleSpaceDelimited}, Value: []int{3, 4, 5}, Expect: "id=3+4+5"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStylePipeDelimited}, Value: []int{3, 4, 5}, Expect: "id=3%7C4%7C5"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleBracket}, Value: []int{3, 4, 5}, Expect: "id%5B%5D=3&id%5B%5D=4&id%5B%5D=5"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleForm, Explode: true}, Value: map[string]interface{}{"role": "admin", "firstName": "Alex"}, Expect: "firstName=Alex&role=admin"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleForm}, Value: map[string]interface{}{"role": "admin", "firstName": "Alex"}, Expect: "id=firstName%2CAlex%2Crole%2Cadmin"})
+	tests = append(tests, QueryEntry{Style: sdkgen.QueryStyle{Style: sdkgen.QueryStyleDeepObject, Explode: true}, Value: filter, Expect: "id%5Bname%5D=foo&id%5Brange%5D%5Bfrom%5D=1&id%5Brange%5D%5Bto%5D=10&id%5Btags%5D%5B%5D=a&id%5Btags%5D%5B%5D=b"})
+
+	for _, test := range tests {
+		var parameters = Map("id", test.Value)
+		var styles = map[string]sdkgen.QueryStyle{"id": test.Style}
+
+		result, err := parser.QueryWithStyle(parameters, []string{}, styles)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		AssertEquals(t, result.Encode(), test.Expect)
+	}
+
+	var parameters = make(map[string]interface{})
+	parameters["ids"] = []int{1, 2}
+	parameters["filter"] = generated.TestObject{Id: 1, Name: "foo"}
+	parameters["search"] = "bar"
+
+	var styles = map[string]sdkgen.QueryStyle{"filter": {Style: sdkgen.QueryStyleDeepObject, Explode: true}}
+
+	result, err := parser.QueryWithStyle(parameters, []string{"filter"}, styles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, result.Encode(), "filter%5Bid%5D=1&filter%5Bname%5D=foo&ids=1&ids=2&search=bar")
+
+	parameters["invalid"] = func() {}
+
+	_, err = parser.QueryWithStyle(parameters, []string{"filter"}, styles)
+	if err == nil {
+		t.Error("expected an error for an unsupported value")
+	}
+}
+
+type Status string
+
+type Level int
+
+func (level Level) String() string {
+	return [...]string{"low", "high"}[level]
+}
+
+func TestEncodeValue(t *testing.T) {
+	var status = Status("active")
+	var count uint16 = 12
+	var nilPointer *int
+	var name = "foo"
+
+	var tests = map[string]interface{}{
+		"active":               status,
+		"12":                   count,
+		"high":                 Level(1),
+		"127.0.0.1":            net.ParseIP("127.0.0.1"),
+		"1,2,3":                []int{1, 2, 3},
+		"2023-02-21":           sdkgen.Date{Time: time.Date(2023, 2, 21, 19, 19, 0, 0, time.UTC)},
+		"19:19:00":             sdkgen.Time{Time: time.Date(2023, 2, 21, 19, 19, 0, 0, time.UTC)},
+		"2023-02-21T19:19:00Z": sdkgen.DateTime{Time: time.Date(2023, 2, 21, 19, 19, 0, 0, time.UTC)},
+		"1.5":                  float32(1.5),
+		"":                     nilPointer,
+		"foo":                  &name,
+		"42":                   json.RawMessage(`42`),
+	}
+
+	for expect, value := range tests {
+		got, err := sdkgen.EncodeValue(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		AssertEquals(t, got, expect)
+	}
+
+	_, err := sdkgen.EncodeValue(generated.TestObject{})
+
+	var unsupported *sdkgen.UnsupportedValueError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("expected an unsupported value error, got %v", err)
+	}
+}
+
+func TestEncodeValueNilPointer(t *testing.T) {
+	var dateTime *time.Time
+	var date *sdkgen.Date
+
+	got, err := sdkgen.EncodeValue(dateTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, got, "")
+
+	got, err = sdkgen.EncodeValue(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, got, "")
+
+	var parameters = make(map[string]interface{})
+	parameters["since"] = dateTime
+	parameters["date"] = date
+
+	query := sdkgen.NewParser("https://api.acme.com").Query(parameters)
+
+	AssertEquals(t, query.Encode(), "date=&since=")
+
+	expanded, err := sdkgen.ExpandUriTemplate("/entries{?since,date}", parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, expanded, "/entries")
+}
+
+type QueryEntry struct {
+	Style  sdkgen.QueryStyle
+	Value  interface{}
+	Expect string
+}
+
 func Map(key string, value interface{}) map[string]interface{} {
 	var params = make(map[string]interface{})
 	params[key] = value
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generated.TestResponse{Data: string(raw)})
+	}))
+	defer server.Close()
+
+	var mutex sync.Mutex
+	var uploads []sdkgen.Progress
+	var downloads []sdkgen.Progress
+	var progress = func(progress sdkgen.Progress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if progress.Direction == sdkgen.ProgressUpload {
+			uploads = append(uploads, progress)
+		} else {
+			downloads = append(downloads, progress)
+		}
+	}
+
+	client, _ := generated.NewClient(server.URL, sdkgen.Anonymous{})
+
+	var payload = []byte(strings.Repeat("a", 1024*128))
+	var ctx = sdkgen.WithProgress(context.Background(), progress)
+
+	response, err := client.Product().BinaryWithContext(ctx, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Data, string(payload))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(uploads) == 0 || len(downloads) == 0 {
+		t.Fatalf("expected upload and download progress, got %d and %d", len(uploads), len(downloads))
+	}
+
+	var upload = uploads[len(uploads)-1]
+	if !upload.Done || upload.Bytes != int64(len(payload)) || upload.Total != int64(len(payload)) {
+		t.Errorf("got unexpected final upload progress %+v", upload)
+	}
+
+	var download = downloads[len(downloads)-1]
+	if !download.Done || download.Bytes <= int64(len(payload)) {
+		t.Errorf("got unexpected final download progress %+v", download)
+	}
+}
+
+func TestProgressChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generated.TestResponse{Method: r.Method})
+	}))
+	defer server.Close()
+
+	var channel = make(chan sdkgen.Progress, 8)
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Progress: sdkgen.ProgressChannel(channel),
+	})
+
+	_, err := client.Product().Delete(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress = <-channel
+	for !progress.Done {
+		progress = <-channel
+	}
+
+	AssertEquals(t, progress.Direction, sdkgen.ProgressDownload)
+
+	if !progress.Done || progress.Bytes != progress.Total {
+		t.Errorf("got unexpected progress %+v", progress)
+	}
+}
+
+func TestProgressChannelWithoutConsumer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generated.TestResponse{Method: r.Method})
+	}))
+	defer server.Close()
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Progress: sdkgen.ProgressChannel(make(chan sdkgen.Progress)),
+	})
+
+	var done = make(chan error)
+	go func() {
+		_, err := client.Product().Delete(1)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request is blocked by the progress channel")
+	}
+}
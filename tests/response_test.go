@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			// without flushing the server would send a content length which is rejected before reading the body
+			w.(http.Flusher).Flush()
+		}
+
+		w.Write([]byte("{\"data\":\"" + strings.Repeat("a", 1024) + "\"}"))
+	}))
+	defer server.Close()
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		MaxResponseSize: 512,
+	})
+
+	var tooLarge *sdkgen.ResponseTooLargeError
+
+	_, err := client.Product().GetAll(0, 16, "")
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 512 {
+		t.Errorf("expected a response too large error while reading, got %v", err)
+	}
+
+	_, err = client.Product().Delete(1)
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 512 {
+		t.Errorf("expected a response too large error from the content length, got %v", err)
+	}
+}
+
+func TestDecodeJson(t *testing.T) {
+	var object generated.TestObject
+	err := sdkgen.DecodeJson(strings.NewReader("{\"id\":1,\"name\":\"foo\"}\n"), &object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "foo")
+
+	err = sdkgen.DecodeJson(strings.NewReader("{\"id\":1} {\"id\":2}"), &object)
+	if err == nil {
+		t.Error("expected an error for trailing data")
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(404)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("foobar"))
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+
+	resp, err := client.HttpClient.Get(server.URL + "/download")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := sdkgen.StreamResponse(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := io.ReadAll(body)
+	body.Close()
+
+	AssertEquals(t, string(raw), "foobar")
+
+	resp, err = client.HttpClient.Get(server.URL + "/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sdkgen.StreamResponse(resp)
+	if err == nil {
+		t.Error("expected an error for a not found response")
+	}
+}
+
+func TestClientDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/anything/2/download" {
+			w.WriteHeader(500)
+			w.Write([]byte("failed"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte(strings.Repeat("a", 1024*64)))
+	}))
+	defer server.Close()
+
+	var bodies []*closeTracker
+	var transport = sdkgen.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		var body = &closeTracker{ReadCloser: resp.Body}
+		bodies = append(bodies, body)
+		resp.Body = body
+
+		return resp, nil
+	})
+
+	client, _ := generated.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Transport: transport,
+	})
+
+	body, err := client.Product().Download(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bodies[0].closed {
+		t.Fatal("expected that the body is not closed before it was read")
+	}
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body.Close()
+
+	if len(raw) != 1024*64 || !bodies[0].closed {
+		t.Errorf("expected a closed body with %d bytes, got %d bytes", 1024*64, len(raw))
+	}
+
+	_, err = client.Product().Download(2)
+
+	var exception *generated.BinaryException
+	if !errors.As(err, &exception) || string(exception.Payload) != "failed" || !bodies[1].closed {
+		t.Errorf("expected a closed body and a binary exception, got %v", err)
+	}
+}
+
+type closeTracker struct {
+	io.ReadCloser
+	closed bool
+}
+
+func (tracker *closeTracker) Close() error {
+	tracker.closed = true
+	return tracker.ReadCloser.Close()
+}
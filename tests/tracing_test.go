@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			json.NewEncoder(w).Encode(sdkgen.AccessToken{TokenType: "bearer", AccessToken: "my_token", ExpiresIn: 3600})
+			return
+		}
+
+		json.NewEncoder(w).Encode(generated.TestResponse{
+			Method: r.Method,
+			Headers: &generated.TestMapScalar{
+				"Authorization": r.Header.Get("Authorization"),
+				"Traceparent":   r.Header.Get("traceparent"),
+			},
+		})
+	}))
+	defer server.Close()
+
+	var tracer = sdkgen.NewMemoryTracer()
+	var credentials = sdkgen.OAuth2{
+		ClientId:     "foo",
+		ClientSecret: "bar",
+		TokenUrl:     server.URL + "/token",
+		TokenStore:   sdkgen.NewMemoryTokenStore(),
+	}
+
+	client, _ := generated.NewClientWithOptions(server.URL, credentials, sdkgen.ClientOptions{
+		Tracer: tracer,
+	})
+
+	response, err := client.Product().Delete(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spans = tracer.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected two spans, got %d", len(spans))
+	}
+
+	var request = spans[0]
+	var token = spans[1]
+	var headers = *response.Headers
+
+	AssertEquals(t, headers["Authorization"], "Bearer my_token")
+	AssertEquals(t, headers["Traceparent"], request.GetSpanContext().GetTraceParent())
+	AssertEquals(t, request.Name, "DELETE /anything/:id")
+	AssertEquals(t, request.Attributes["url.template"].(string), "/anything/:id")
+	AssertEquals(t, request.Attributes["sdkgen.operation"].(string), "ProductTag.Delete")
+	AssertEquals(t, request.Attributes["http.request.method"].(string), "DELETE")
+	AssertEquals(t, request.Attributes["url.full"].(string), server.URL+"/anything/1")
+
+	_, err = client.Product().GetAll(0, 16, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, tracer.GetSpans()[2].Attributes["url.full"].(string), server.URL+"/anything?count=[REDACTED]&search=[REDACTED]&startIndex=[REDACTED]")
+	AssertEquals(t, token.Name, "oauth2 token")
+	AssertEquals(t, token.ParentId, request.GetSpanContext().SpanId)
+	AssertEquals(t, token.GetSpanContext().TraceId, request.GetSpanContext().TraceId)
+	AssertEquals(t, token.Attributes["oauth2.grant_type"].(string), "client_credentials")
+
+	if request.Attributes["http.response.status_code"] != 200 || !request.Ended || !token.Ended {
+		t.Errorf("expected ended spans with a status code, got %v", request.Attributes["http.response.status_code"])
+	}
+}
+
+func TestTracingSharedAuthenticator(t *testing.T) {
+	var tracer = sdkgen.NewMemoryTracer()
+	var authenticator = &sdkgen.OAuth2Authenticator{Tracer: tracer}
+
+	sdkgen.HttpClientFactoryWithOptions(authenticator, sdkgen.ClientOptions{Tracer: sdkgen.NewMemoryTracer(), Metrics: sdkgen.NewMemoryMetrics()})
+	sdkgen.HttpClientFactoryWithOptions(authenticator, sdkgen.ClientOptions{})
+
+	if authenticator.Tracer != tracer || authenticator.Metrics != nil {
+		t.Errorf("expected that the factory does not modify a shared authenticator")
+	}
+}
+
+func TestTracingRequestHeader(t *testing.T) {
+	var traceParents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+	}))
+	defer server.Close()
+
+	client, _ := sdkgen.NewClientWithOptions(server.URL, sdkgen.Anonymous{}, sdkgen.ClientOptions{
+		Tracer: sdkgen.NewMemoryTracer(),
+	})
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	for i := 0; i < 2; i++ {
+		resp, err := client.HttpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp.Body.Close()
+	}
+
+	if req.Header.Get("traceparent") != "" {
+		t.Errorf("expected that the header of the request is not modified, got %s", req.Header.Get("traceparent"))
+	}
+
+	if len(traceParents) != 2 || traceParents[0] == "" || traceParents[0] == traceParents[1] {
+		t.Errorf("expected a new trace parent for every request, got %v", traceParents)
+	}
+}
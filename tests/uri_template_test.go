@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apioo/sdkgen-go/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UriTemplateSuite describes a file in the format of the uritemplate-test suite, the expected value of a test case is
+// either a string, a list of possible results or false in case the template is invalid
+type UriTemplateSuite map[string]struct {
+	Level     int                    `json:"level"`
+	Variables map[string]interface{} `json:"variables"`
+	Testcases [][2]interface{}       `json:"testcases"`
+}
+
+func TestUriTemplate(t *testing.T) {
+	files, err := filepath.Glob("testdata/uritemplate/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("found no test data")
+	}
+
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var suite UriTemplateSuite
+		err = json.Unmarshal(raw, &suite)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, group := range suite {
+			for _, testcase := range group.Testcases {
+				var template = testcase[0].(string)
+
+				actual, err := sdkgen.ExpandUriTemplate(template, group.Variables)
+
+				switch expect := testcase[1].(type) {
+				case bool:
+					if err == nil {
+						t.Errorf("%s: %s: expected an error, got %q", name, template, actual)
+					}
+				case string:
+					if err != nil {
+						t.Errorf("%s: %s: %s", name, template, err)
+					} else if actual != expect {
+						t.Errorf("%s: %s: got %q, wanted %q", name, template, actual, expect)
+					}
+				case []interface{}:
+					if err != nil {
+						t.Errorf("%s: %s: %s", name, template, err)
+					} else if !ContainsValue(expect, actual) {
+						t.Errorf("%s: %s: got %q, wanted one of %q", name, template, actual, expect)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestUriTemplateVariables(t *testing.T) {
+	template, err := sdkgen.ParseUriTemplate("/files{/path*}{?q,limit}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parameters = make(map[string]interface{})
+	parameters["path"] = []string{"a b", "c/d"}
+	parameters["q"] = "x?y"
+	parameters["limit"] = 10
+
+	actual, err := template.Expand(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, actual, "/files/a%20b/c%2Fd?q=x%3Fy&limit=10")
+	AssertEquals(t, fmt.Sprint(template.GetVariables()), "[path q limit]")
+}
+
+func ContainsValue(values []interface{}, needle string) bool {
+	for _, value := range values {
+		if value == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type Account struct {
+	Id       string                   `json:"id" validate:"required,format=uuid"`
+	Email    string                   `json:"email" validate:"required,format=email"`
+	Website  *string                  `json:"website,omitempty" validate:"format=uri"`
+	Age      int                      `json:"age" validate:"min=18,max=130"`
+	Role     string                   `json:"role" validate:"enum=admin|user"`
+	Name     string                   `json:"name" validate:"minLength=2,maxLength=5"`
+	Code     string                   `json:"code" validate:"pattern=^[A-Z]{2,3}$"`
+	Tags     []string                 `json:"tags" validate:"minItems=1"`
+	Birthday *sdkgen.Nullable[string] `json:"birthday,omitempty" validate:"required,format=date"`
+	Created  sdkgen.DateTime          `json:"created"`
+	Owner    *generated.TestObject    `json:"owner" validate:"required"`
+	Objects  []generated.TestObject   `json:"objects"`
+	Password string                   `json:"-" validate:"required"`
+	Limits   Limits                   `json:"limits"`
+}
+
+type Limits struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (limits Limits) Validate() error {
+	if limits.Min > limits.Max {
+		return &sdkgen.ValidationError{Path: "min", Rule: "range", Message: "must be less than or equal to max"}
+	}
+
+	return nil
+}
+
+type Period struct {
+	From  string  `json:"from" validate:"required"`
+	To    string  `json:"to"`
+	Range *Limits `json:"range"`
+}
+
+func (period *Period) Validate() error {
+	if period.To != "" && period.To < period.From {
+		return &sdkgen.ValidationError{Path: "to", Rule: "range", Message: "must be after from"}
+	}
+
+	return nil
+}
+
+func (period *Period) String() string {
+	return period.From + "/" + period.To
+}
+
+type Booking struct {
+	Name   string `json:"name"`
+	Period Period `json:"period"`
+}
+
+type InvalidRule struct {
+	Age int `validate:"foo"`
+}
+
+func TestValidate(t *testing.T) {
+	var website = "https://sdkgen.app"
+	var account = Account{
+		Id:       "0f2a5b4e-3c3d-4f8e-9a51-1a4f0b2c3d4e",
+		Email:    "foo@bar.com",
+		Website:  &website,
+		Age:      18,
+		Role:     "admin",
+		Name:     "fööbä",
+		Code:     "AB",
+		Tags:     []string{"foo"},
+		Birthday: sdkgen.Some("2024-09-22"),
+		Owner:    &generated.TestObject{Id: 1, Name: "foo"},
+		Limits:   Limits{Min: 1, Max: 2},
+	}
+
+	err := sdkgen.Validate(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sdkgen.Validate(&account)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	var website = "sdkgen.app"
+	var account = Account{
+		Id:       "0f2a5b4e",
+		Email:    "foo",
+		Website:  &website,
+		Age:      17,
+		Role:     "guest",
+		Name:     "foobar",
+		Code:     "abc",
+		Tags:     []string{},
+		Birthday: sdkgen.Some("22.09.2024"),
+		Objects:  []generated.TestObject{{Id: 1, Name: "foo"}, {Id: 2}},
+		Limits:   Limits{Min: 2, Max: 1},
+	}
+
+	err := sdkgen.Validate(account)
+
+	var validationErrors sdkgen.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+
+	AssertEquals(t, err.Error(), "id: must be a valid uuid; email: must be a valid email; website: must be a valid uri; age: must be greater than or equal to 18; role: must be one of admin, user; name: must contain at most 5 characters; code: must match the pattern ^[A-Z]{2,3}$; tags: must contain at least 1 items; birthday: must be a valid date; owner: the value is required; objects[1].name: the value is required; limits.min: must be less than or equal to max")
+	AssertEquals(t, validationErrors[10].Path, "objects[1].name")
+	AssertEquals(t, validationErrors[10].Rule, "required")
+
+	account.Birthday = nil
+	account.Website = nil
+	err = sdkgen.Validate(account)
+	AssertEquals(t, err.(sdkgen.ValidationErrors)[2].Error(), "age: must be greater than or equal to 18")
+	AssertEquals(t, err.(sdkgen.ValidationErrors)[7].Error(), "birthday: the value is required")
+}
+
+func TestValidateEmpty(t *testing.T) {
+	err := sdkgen.Validate(generated.TestRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sdkgen.Validate(generated.TestRequest{String: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sdkgen.Validate(generated.TestRequest{DateString: "22.09.2024"})
+	AssertEquals(t, err.Error(), "dateString: must be a valid date")
+
+	err = sdkgen.Validate(generated.TestRequest{DateTimeString: "2024-01-01T10:00:00"})
+	AssertEquals(t, err.Error(), "dateTimeString: must be a valid date-time")
+}
+
+func TestValidatePointerReceiver(t *testing.T) {
+	var period = Period{From: "2024-09-22", To: "2024-09-21"}
+
+	err := sdkgen.Validate(period)
+	AssertEquals(t, err.Error(), "to: must be after from")
+
+	err = sdkgen.Validate(&period)
+	AssertEquals(t, err.Error(), "to: must be after from")
+
+	err = sdkgen.Validate(Booking{Name: "foo", Period: Period{To: "2024-09-21"}})
+	AssertEquals(t, err.Error(), "period.from: the value is required")
+
+	err = sdkgen.Validate(Booking{Name: "foo", Period: period})
+	AssertEquals(t, err.Error(), "period.to: must be after from")
+}
+
+func TestValidateInvalidRule(t *testing.T) {
+	err := sdkgen.Validate(InvalidRule{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	AssertEquals(t, err.Error(), "the field tests.InvalidRule.Age contains an invalid rule: the rule foo is not supported")
+}
+
+func TestClientValidate(t *testing.T) {
+	var requests = 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client, err := generated.NewClient(server.URL, sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload = NewPayload()
+	payload.Int = -1
+	payload.DateString = "22.09.2024"
+	payload.ArrayObject[1].Name = ""
+
+	_, err = client.Product().Create(payload)
+
+	AssertEquals(t, err.Error(), "int: must be greater than or equal to 0; dateString: must be a valid date; arrayObject[1].name: the value is required")
+
+	if requests != 0 {
+		t.Errorf("expected that no request was sent, got %d", requests)
+	}
+}
@@ -0,0 +1,233 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebSocket(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, reader := UpgradeWebSocket(t, w, r)
+		defer conn.Close()
+
+		// the ping must be answered before the fragmented message is delivered
+		WriteServerFrame(conn, true, sdkgen.PingMessage, []byte("hello"))
+		WriteServerFrame(conn, false, sdkgen.TextMessage, []byte(`{"id":1,`))
+		WriteServerFrame(conn, true, sdkgen.ContinuationMessage, []byte(`"name":"foo"}`))
+
+		_, opcode, payload, err := ReadClientFrame(reader)
+		if err != nil || opcode != sdkgen.PongMessage || string(payload) != "hello" {
+			t.Errorf("expected a pong, got opcode %d with %q", opcode, payload)
+			return
+		}
+
+		_, opcode, payload, err = ReadClientFrame(reader)
+		if err != nil || opcode != sdkgen.TextMessage {
+			t.Errorf("expected a text message, got opcode %d", opcode)
+			return
+		}
+
+		WriteServerFrame(conn, true, sdkgen.TextMessage, payload)
+
+		_, opcode, payload, err = ReadClientFrame(reader)
+		if err != nil || opcode != sdkgen.CloseMessage {
+			t.Errorf("expected a close frame, got opcode %d", opcode)
+			return
+		}
+
+		WriteServerFrame(conn, true, sdkgen.CloseMessage, payload)
+	}))
+
+	defer server.Close()
+
+	var dialer = sdkgen.NewWebSocketDialer(&sdkgen.HttpBearerAuthenticator{Credentials: sdkgen.HttpBearer{Token: "my_token"}})
+
+	ws, err := dialer.Dial(context.Background(), strings.Replace(server.URL, "http://", "ws://", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object, err := sdkgen.ReadJsonMessage[generated.TestObject](ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, object.Name, "foo")
+
+	err = ws.WriteJson(generated.TestObject{Id: 2, Name: "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageType, data, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if messageType != sdkgen.TextMessage {
+		t.Errorf("expected a text message, got %d", messageType)
+	}
+
+	AssertEquals(t, string(data), `{"id":2,"name":"bar"}`)
+
+	err = ws.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ws.WriteJson(object)
+	if err == nil {
+		t.Error("expected an error after the connection was closed")
+	}
+}
+
+func TestWebSocketServerClose(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, reader := UpgradeWebSocket(t, w, r)
+		defer conn.Close()
+
+		var payload = make([]byte, 2)
+		binary.BigEndian.PutUint16(payload, sdkgen.CloseGoingAway)
+		WriteServerFrame(conn, true, sdkgen.CloseMessage, append(payload, "shutdown"...))
+
+		_, opcode, payload, err := ReadClientFrame(reader)
+		if err != nil || opcode != sdkgen.CloseMessage || binary.BigEndian.Uint16(payload) != sdkgen.CloseGoingAway {
+			t.Errorf("expected the close to be confirmed, got opcode %d", opcode)
+		}
+	}))
+
+	defer server.Close()
+
+	client, err := sdkgen.NewClient(server.URL, sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := client.DialWebSocket(context.Background(), "/", map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = ws.ReadMessage()
+
+	var closeError *sdkgen.WebSocketCloseError
+	if !errors.As(err, &closeError) {
+		t.Fatalf("expected a close error, got %v", err)
+	}
+
+	if closeError.Code != sdkgen.CloseGoingAway {
+		t.Errorf("expected the code %d, got %d", sdkgen.CloseGoingAway, closeError.Code)
+	}
+
+	AssertEquals(t, closeError.Reason, "shutdown")
+
+	err = ws.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebSocketHandshakeFailure(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	defer server.Close()
+
+	_, err := sdkgen.NewWebSocketDialer(nil).Dial(context.Background(), strings.Replace(server.URL, "http://", "ws://", 1))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	AssertEquals(t, err.Error(), "the websocket handshake failed with status code: 401")
+}
+
+func UpgradeWebSocket(t *testing.T, w http.ResponseWriter, r *http.Request) (net.Conn, *bufio.Reader) {
+	if r.Header.Get("Upgrade") != "websocket" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		t.Error("expected a websocket upgrade request")
+	}
+
+	conn, buffer, _ := w.(http.Hijacker).Hijack()
+
+	var hash = sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+
+	_, _ = buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(hash[:]) + "\r\n\r\n")
+	_ = buffer.Flush()
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return conn, buffer.Reader
+}
+
+func ReadClientFrame(reader *bufio.Reader) (bool, int, []byte, error) {
+	var header = make([]byte, 2)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, errors.New("client frames must be masked")
+	}
+
+	var length = uint64(header[1] & 0x7f)
+	if length == 126 {
+		var extended = make([]byte, 2)
+		_, err = io.ReadFull(reader, extended)
+		length = uint64(binary.BigEndian.Uint16(extended))
+	} else if length == 127 {
+		var extended = make([]byte, 8)
+		_, err = io.ReadFull(reader, extended)
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	var mask = make([]byte, 4)
+	_, err = io.ReadFull(reader, mask)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	var payload = make([]byte, length)
+	_, err = io.ReadFull(reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	for index := range payload {
+		payload[index] ^= mask[index%4]
+	}
+
+	return header[0]&0x80 != 0, int(header[0] & 0x0f), payload, nil
+}
+
+func WriteServerFrame(conn net.Conn, fin bool, opcode int, payload []byte) {
+	var first = byte(opcode)
+	if fin {
+		first |= 0x80
+	}
+
+	// all payloads of the tests are smaller than 126 bytes
+	_, _ = conn.Write(append([]byte{first, byte(len(payload))}, payload...))
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apioo/sdkgen-go/v2"
+	"github.com/apioo/sdkgen-go/v2/tests/generated"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestXmlCodec(t *testing.T) {
+	var codec = sdkgen.NewXmlCodec()
+	var object = generated.TestXmlObject{Id: 1, Name: "foo", Tags: []string{"a", "b"}}
+
+	raw, err := codec.Encode(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), `<product xmlns="urn:sdkgen:test" id="1"><name>foo</name><tags><tag>a</tag><tag>b</tag></tags></product>`)
+
+	codec.Header = true
+	raw, err = codec.Encode(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, string(raw), "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+`<product xmlns="urn:sdkgen:test" id="1"><name>foo</name><tags><tag>a</tag><tag>b</tag></tags></product>`)
+
+	var result generated.TestXmlObject
+	err = codec.Decode(strings.NewReader(string(raw)), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, result.Name, "foo")
+	AssertEquals(t, strings.Join(result.Tags, ","), "a,b")
+	AssertEquals(t, result.XMLName.Space, "urn:sdkgen:test")
+}
+
+func TestXmlCodecCharset(t *testing.T) {
+	var result generated.TestXmlObject
+	err := sdkgen.NewXmlCodec().Decode(strings.NewReader("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><product xmlns=\"urn:sdkgen:test\" id=\"2\"><name>M\xfcller</name></product>"), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, result.Name, "Müller")
+
+	err = sdkgen.NewXmlCodec().Decode(strings.NewReader(`<product xmlns="urn:other"><name>foo</name></product>`), &result)
+	if err == nil {
+		t.Error("expected an error for a different namespace")
+	}
+}
+
+func TestParserCodecFor(t *testing.T) {
+	var parser = sdkgen.NewParser("")
+
+	if _, ok := parser.GetCodecFor("application/soap+xml; charset=utf-8").(*sdkgen.XmlCodec); !ok {
+		t.Error("expected the xml codec for application/soap+xml")
+	}
+
+	if _, ok := parser.GetCodecFor("text/xml").(*sdkgen.XmlCodec); !ok {
+		t.Error("expected the xml codec for text/xml")
+	}
+
+	if _, ok := parser.GetCodecFor("application/json").(*sdkgen.JsonCodec); !ok {
+		t.Error("expected the json codec for application/json")
+	}
+
+	if _, ok := parser.GetCodecFor("").(*sdkgen.JsonCodec); !ok {
+		t.Error("expected the default codec for an empty content type")
+	}
+
+	var codec = &sdkgen.XmlCodec{Header: true}
+	parser.RegisterCodec(codec)
+
+	if parser.GetCodecFor("application/xml") != codec {
+		t.Error("expected the registered codec")
+	}
+
+	AssertEquals(t, parser.Accept("application/xml"), "application/xml")
+	AssertEquals(t, parser.Accept("application/xml", "application/json", "text/plain"), "application/xml, application/json;q=0.9, text/plain;q=0.8")
+}
+
+func TestClientXmlObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch strings.TrimSuffix(r.URL.Path, "/anything/xml") {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(generated.TestXmlObject{Id: 2, Name: r.Header.Get("Accept")})
+		case "/error":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<error code="42"><message>invalid product</message></error>`))
+		default:
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(strings.Replace(string(body), `id="1"`, `id="3"`, 1)))
+		}
+	}))
+	defer server.Close()
+
+	client, err := generated.NewClient(server.URL, sdkgen.Anonymous{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload = generated.TestXmlObject{Id: 1, Name: "foo", Tags: []string{"a"}}
+	response, err := client.Product().XmlObject(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Name, "foo")
+	AssertEquals(t, response.Tags[0], "a")
+	if response.Id != 3 {
+		t.Errorf("got id %d, wanted 3", response.Id)
+	}
+
+	client, _ = generated.NewClient(server.URL+"/json", sdkgen.Anonymous{})
+	response, err = client.Product().XmlObject(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AssertEquals(t, response.Name, "application/xml, application/json;q=0.9")
+
+	client, _ = generated.NewClient(server.URL+"/error", sdkgen.Anonymous{})
+	_, err = client.Product().XmlObject(payload)
+
+	var exception *generated.TestXmlErrorException
+	if !errors.As(err, &exception) {
+		t.Fatalf("expected a xml error exception, got %v", err)
+	}
+
+	AssertEquals(t, exception.Payload.Message, "invalid product")
+	AssertEquals(t, err.Error(), `The server returned an error: {"code":42,"message":"invalid product"}`)
+
+	_, err = client.Product().Xml("<foo>bar</foo>")
+	if !errors.As(err, &exception) {
+		t.Fatalf("expected a xml error exception, got %v", err)
+	}
+
+	AssertEquals(t, exception.Payload.Message, "invalid product")
+}
@@ -0,0 +1,210 @@
+package sdkgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SpanContext contains the W3C trace context of a span which is propagated through the traceparent and tracestate
+// headers
+type SpanContext struct {
+	TraceId    string
+	SpanId     string
+	Sampled    bool
+	TraceState string
+}
+
+func (spanContext SpanContext) IsValid() bool {
+	return len(spanContext.TraceId) == 32 && len(spanContext.SpanId) == 16
+}
+
+func (spanContext SpanContext) GetTraceParent() string {
+	var flags = "00"
+	if spanContext.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + spanContext.TraceId + "-" + spanContext.SpanId + "-" + flags
+}
+
+type SpanInterface interface {
+	GetSpanContext() SpanContext
+	SetAttribute(key string, value interface{})
+	SetError(err error)
+	End()
+}
+
+// TracerInterface starts client spans for every request and token fetch, the returned context must contain the new
+// span so that nested spans use it as parent. An OpenTelemetry adapter is available at the otel sub-module
+type TracerInterface interface {
+	Start(ctx context.Context, name string) (context.Context, SpanInterface)
+}
+
+func traceRequest(tracer TracerInterface, req *http.Request, next RoundTripFunc) (*http.Response, error) {
+	var name = req.Method
+	operation, ok := GetOperation(req.Context())
+	if ok && operation.Path != "" {
+		name = req.Method + " " + operation.Path
+	}
+
+	ctx, span := tracer.Start(req.Context(), name)
+	defer span.End()
+
+	// the request is cloned so that the trace headers are not added to the header map of the caller
+	req = req.Clone(ctx)
+
+	if ok {
+		span.SetAttribute("sdkgen.operation", operation.GetFullName())
+		if operation.Path != "" {
+			span.SetAttribute("url.template", operation.Path)
+		}
+	}
+
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("url.full", redactTraceUrl(req.URL))
+	span.SetAttribute("server.address", req.URL.Hostname())
+	if port := req.URL.Port(); port != "" {
+		number, err := strconv.Atoi(port)
+		if err == nil {
+			span.SetAttribute("server.port", number)
+		}
+	}
+
+	if attempt := GetAttempt(ctx); attempt > 1 {
+		span.SetAttribute("http.request.resend_count", attempt-1)
+	}
+
+	var spanContext = span.GetSpanContext()
+	if spanContext.IsValid() {
+		req.Header.Set("traceparent", spanContext.GetTraceParent())
+		if spanContext.TraceState != "" {
+			req.Header.Set("tracestate", spanContext.TraceState)
+		}
+	}
+
+	resp, err := next(req)
+	if err != nil {
+		span.SetAttribute("error.type", "transport")
+		span.SetError(err)
+		return resp, err
+	}
+
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		span.SetAttribute("error.type", strconv.Itoa(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// redactTraceUrl removes the credentials and the values of all query parameters since they may contain secrets like an
+// API key, the names of the parameters are kept
+func redactTraceUrl(u *url.URL) string {
+	var redactedUrl = *u
+	redactedUrl.User = nil
+	if u.RawQuery != "" {
+		var parts = strings.Split(u.RawQuery, "&")
+		for i, part := range parts {
+			name, _, found := strings.Cut(part, "=")
+			if found {
+				parts[i] = name + "=" + redacted
+			}
+		}
+
+		redactedUrl.RawQuery = strings.Join(parts, "&")
+	}
+
+	return redactedUrl.String()
+}
+
+type MemorySpan struct {
+	Name       string
+	ParentId   string
+	Attributes map[string]interface{}
+	Error      error
+	Ended      bool
+	context    SpanContext
+	mutex      sync.Mutex
+}
+
+func (span *MemorySpan) GetSpanContext() SpanContext {
+	return span.context
+}
+
+func (span *MemorySpan) SetAttribute(key string, value interface{}) {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	span.Attributes[key] = value
+}
+
+func (span *MemorySpan) SetError(err error) {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	span.Error = err
+}
+
+func (span *MemorySpan) End() {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	span.Ended = true
+}
+
+// MemoryTracer records all spans in memory, this is useful for tests or to inspect the spans of a single request
+type MemoryTracer struct {
+	spans []*MemorySpan
+	mutex sync.Mutex
+}
+
+type memorySpanKey struct{}
+
+func (tracer *MemoryTracer) Start(ctx context.Context, name string) (context.Context, SpanInterface) {
+	var span = &MemorySpan{
+		Name:       name,
+		Attributes: make(map[string]interface{}),
+		context: SpanContext{
+			TraceId: randomHex(16),
+			SpanId:  randomHex(8),
+			Sampled: true,
+		},
+	}
+
+	parent, ok := ctx.Value(memorySpanKey{}).(*MemorySpan)
+	if ok {
+		span.ParentId = parent.context.SpanId
+		span.context.TraceId = parent.context.TraceId
+		span.context.TraceState = parent.context.TraceState
+	}
+
+	tracer.mutex.Lock()
+	tracer.spans = append(tracer.spans, span)
+	tracer.mutex.Unlock()
+
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+func (tracer *MemoryTracer) GetSpans() []*MemorySpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	return append([]*MemorySpan{}, tracer.spans...)
+}
+
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+func randomHex(size int) string {
+	var data = make([]byte, size)
+	_, _ = rand.Read(data)
+
+	return hex.EncodeToString(data)
+}
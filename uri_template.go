@@ -0,0 +1,434 @@
+package sdkgen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UriTemplate is a parsed URI template according to RFC 6570, all operators of level 4 are supported
+type UriTemplate struct {
+	parts []uriTemplatePart
+}
+
+// Expand expands the template with the provided variables, a variable can be a scalar value, a slice which is handled
+// as list or a map which is handled as associative array. The keys of a map are expanded in sorted order
+func (template *UriTemplate) Expand(variables map[string]interface{}) (string, error) {
+	var result strings.Builder
+	for _, part := range template.parts {
+		if part.expression == nil {
+			result.WriteString(part.literal)
+			continue
+		}
+
+		err := part.expression.expand(&result, variables)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return result.String(), nil
+}
+
+// GetVariables returns the names of all variables which are used in the template
+func (template *UriTemplate) GetVariables() []string {
+	var names []string
+	for _, part := range template.parts {
+		if part.expression == nil {
+			continue
+		}
+
+		for _, varspec := range part.expression.varspecs {
+			names = append(names, varspec.name)
+		}
+	}
+
+	return names
+}
+
+func ParseUriTemplate(template string) (*UriTemplate, error) {
+	var parts []uriTemplatePart
+	var literal strings.Builder
+
+	for pos := 0; pos < len(template); {
+		var char = template[pos]
+		if char == '{' {
+			end := strings.IndexByte(template[pos:], '}')
+			if end == -1 {
+				return nil, errors.New("the template contains an unclosed expression at position " + strconv.Itoa(pos))
+			}
+
+			expression, err := parseUriTemplateExpression(template[pos+1 : pos+end])
+			if err != nil {
+				return nil, err
+			}
+
+			if literal.Len() > 0 {
+				parts = append(parts, uriTemplatePart{literal: literal.String()})
+				literal.Reset()
+			}
+
+			parts = append(parts, uriTemplatePart{expression: expression})
+			pos += end + 1
+			continue
+		}
+
+		if char == '%' {
+			if pos+2 >= len(template) || !isHex(template[pos+1]) || !isHex(template[pos+2]) {
+				return nil, errors.New("the template contains an invalid percent encoding at position " + strconv.Itoa(pos))
+			}
+
+			literal.WriteString(template[pos : pos+3])
+			pos += 3
+			continue
+		}
+
+		if strings.IndexByte(" \"'<>\\^`{|}", char) != -1 || char < 0x20 || char == 0x7f {
+			return nil, errors.New("the template contains an invalid character at position " + strconv.Itoa(pos))
+		}
+
+		if char >= utf8.RuneSelf {
+			// characters outside of ASCII are allowed as literal but must be encoded in the URI
+			r, size := utf8.DecodeRuneInString(template[pos:])
+			literal.WriteString(encodeUriTemplateValue(string(r), false))
+			pos += size
+			continue
+		}
+
+		literal.WriteByte(char)
+		pos++
+	}
+
+	if literal.Len() > 0 {
+		parts = append(parts, uriTemplatePart{literal: literal.String()})
+	}
+
+	return &UriTemplate{parts: parts}, nil
+}
+
+// ExpandUriTemplate parses and expands the template
+func ExpandUriTemplate(template string, variables map[string]interface{}) (string, error) {
+	parsed, err := ParseUriTemplate(template)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.Expand(variables)
+}
+
+type uriTemplatePart struct {
+	literal    string
+	expression *uriTemplateExpression
+}
+
+type uriTemplateOperator struct {
+	first         string
+	separator     string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var uriTemplateOperators = map[byte]uriTemplateOperator{
+	0:   {first: "", separator: ",", named: false, ifEmpty: "", allowReserved: false},
+	'+': {first: "", separator: ",", named: false, ifEmpty: "", allowReserved: true},
+	'.': {first: ".", separator: ".", named: false, ifEmpty: "", allowReserved: false},
+	'/': {first: "/", separator: "/", named: false, ifEmpty: "", allowReserved: false},
+	';': {first: ";", separator: ";", named: true, ifEmpty: "", allowReserved: false},
+	'?': {first: "?", separator: "&", named: true, ifEmpty: "=", allowReserved: false},
+	'&': {first: "&", separator: "&", named: true, ifEmpty: "=", allowReserved: false},
+	'#': {first: "#", separator: ",", named: false, ifEmpty: "", allowReserved: true},
+}
+
+type uriTemplateVarspec struct {
+	name    string
+	explode bool
+	prefix  int
+}
+
+type uriTemplateExpression struct {
+	operator uriTemplateOperator
+	varspecs []uriTemplateVarspec
+}
+
+func (expression *uriTemplateExpression) expand(result *strings.Builder, variables map[string]interface{}) error {
+	var operator = expression.operator
+	var first = true
+
+	for _, varspec := range expression.varspecs {
+		value, defined, err := normalizeUriTemplateValue(variables[varspec.name])
+		if err != nil {
+			return err
+		} else if !defined {
+			continue
+		}
+
+		if first {
+			result.WriteString(operator.first)
+			first = false
+		} else {
+			result.WriteString(operator.separator)
+		}
+
+		switch value := value.(type) {
+		case string:
+			if operator.named {
+				result.WriteString(varspec.name)
+				if value == "" {
+					result.WriteString(operator.ifEmpty)
+					continue
+				}
+
+				result.WriteString("=")
+			}
+
+			if varspec.prefix > 0 {
+				value = prefixString(value, varspec.prefix)
+			}
+
+			result.WriteString(encodeUriTemplateValue(value, operator.allowReserved))
+		case []string:
+			if varspec.prefix > 0 {
+				return errors.New("a prefix modifier can not be applied to the list variable " + varspec.name)
+			}
+
+			var items = make([]string, 0, len(value))
+			for _, item := range value {
+				if varspec.explode && operator.named {
+					items = append(items, namedPair(varspec.name, encodeUriTemplateValue(item, operator.allowReserved), operator.ifEmpty))
+				} else {
+					items = append(items, encodeUriTemplateValue(item, operator.allowReserved))
+				}
+			}
+
+			if varspec.explode {
+				result.WriteString(strings.Join(items, operator.separator))
+			} else {
+				if operator.named {
+					result.WriteString(varspec.name + "=")
+				}
+
+				result.WriteString(strings.Join(items, ","))
+			}
+		case [][2]string:
+			if varspec.prefix > 0 {
+				return errors.New("a prefix modifier can not be applied to the associative variable " + varspec.name)
+			}
+
+			var items = make([]string, 0, len(value)*2)
+			for _, pair := range value {
+				var key = encodeUriTemplateValue(pair[0], operator.allowReserved)
+				var item = encodeUriTemplateValue(pair[1], operator.allowReserved)
+				if varspec.explode {
+					if operator.named {
+						items = append(items, namedPair(key, item, operator.ifEmpty))
+					} else {
+						items = append(items, key+"="+item)
+					}
+				} else {
+					items = append(items, key, item)
+				}
+			}
+
+			if varspec.explode {
+				result.WriteString(strings.Join(items, operator.separator))
+			} else {
+				if operator.named {
+					result.WriteString(varspec.name + "=")
+				}
+
+				result.WriteString(strings.Join(items, ","))
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseUriTemplateExpression(raw string) (*uriTemplateExpression, error) {
+	if raw == "" {
+		return nil, errors.New("the template contains an empty expression")
+	}
+
+	var operatorChar byte = 0
+	if strings.IndexByte("+#./;?&", raw[0]) != -1 {
+		operatorChar = raw[0]
+		raw = raw[1:]
+	} else if strings.IndexByte("=,!@|", raw[0]) != -1 {
+		return nil, errors.New("the template contains the reserved operator " + raw[:1])
+	}
+
+	var expression = &uriTemplateExpression{operator: uriTemplateOperators[operatorChar]}
+	for _, spec := range strings.Split(raw, ",") {
+		var varspec = uriTemplateVarspec{name: spec}
+		if strings.HasSuffix(spec, "*") {
+			varspec.name = spec[:len(spec)-1]
+			varspec.explode = true
+		} else if pos := strings.IndexByte(spec, ':'); pos != -1 {
+			prefix, err := strconv.Atoi(spec[pos+1:])
+			if err != nil || prefix < 1 || prefix > 9999 || spec[pos+1] == '0' {
+				return nil, errors.New("the template contains an invalid prefix modifier: " + spec)
+			}
+
+			varspec.name = spec[:pos]
+			varspec.prefix = prefix
+		}
+
+		if !isValidVarname(varspec.name) {
+			return nil, errors.New("the template contains an invalid variable name: " + varspec.name)
+		}
+
+		expression.varspecs = append(expression.varspecs, varspec)
+	}
+
+	return expression, nil
+}
+
+// normalizeUriTemplateValue converts the value into a string, a list or a list of key value pairs, the second return
+// value is false in case the variable is undefined
+func normalizeUriTemplateValue(value interface{}) (interface{}, bool, error) {
+	if isNilValue(value) {
+		return nil, false, nil
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, true, nil
+	case []string:
+		return value, len(value) > 0, nil
+	}
+
+	if isScalarValue(value) {
+		encoded, err := EncodeValue(value)
+		return encoded, true, err
+	}
+
+	var reflected = reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items = make([]string, 0, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			item, err := EncodeValue(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, false, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, len(items) > 0, nil
+	case reflect.Map:
+		var keys = make([]string, 0, reflected.Len())
+		var values = make(map[string]string, reflected.Len())
+		for _, key := range reflected.MapKeys() {
+			name, err := EncodeValue(key.Interface())
+			if err != nil {
+				return nil, false, err
+			}
+
+			item, err := EncodeValue(reflected.MapIndex(key).Interface())
+			if err != nil {
+				return nil, false, err
+			}
+
+			keys = append(keys, name)
+			values[name] = item
+		}
+
+		sort.Strings(keys)
+
+		var pairs = make([][2]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, [2]string{key, values[key]})
+		}
+
+		return pairs, len(pairs) > 0, nil
+	case reflect.Ptr:
+		if reflected.IsNil() {
+			return nil, false, nil
+		}
+
+		return normalizeUriTemplateValue(reflected.Elem().Interface())
+	}
+
+	encoded, err := EncodeValue(value)
+
+	return encoded, true, err
+}
+
+func encodeUriTemplateValue(value string, allowReserved bool) string {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		var char = value[i]
+		if isUnreserved(char) {
+			result.WriteByte(char)
+		} else if allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", char) != -1 {
+			result.WriteByte(char)
+		} else if allowReserved && char == '%' && i+2 < len(value) && isHex(value[i+1]) && isHex(value[i+2]) {
+			// pct-encoded triplets are passed through in case reserved characters are allowed
+			result.WriteString(value[i : i+3])
+			i += 2
+		} else {
+			result.WriteString(fmt.Sprintf("%%%02X", char))
+		}
+	}
+
+	return result.String()
+}
+
+func namedPair(name string, value string, ifEmpty string) string {
+	if value == "" {
+		return name + ifEmpty
+	}
+
+	return name + "=" + value
+}
+
+func prefixString(value string, length int) string {
+	var count = 0
+	for pos := range value {
+		if count == length {
+			return value[:pos]
+		}
+
+		count++
+	}
+
+	return value
+}
+
+func isValidVarname(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' || strings.Contains(name, "..") {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		var char = name[i]
+		if char == '%' {
+			if i+2 >= len(name) || !isHex(name[i+1]) || !isHex(name[i+2]) {
+				return false
+			}
+
+			i += 2
+			continue
+		}
+
+		if !(char >= 'a' && char <= 'z') && !(char >= 'A' && char <= 'Z') && !(char >= '0' && char <= '9') && char != '_' && char != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUnreserved(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') || char == '-' || char == '.' || char == '_' || char == '~'
+}
+
+func isHex(char byte) bool {
+	return (char >= '0' && char <= '9') || (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F')
+}
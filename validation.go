@@ -0,0 +1,465 @@
+package sdkgen
+
+import (
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidatorInterface can be implemented by a model to add custom checks, the method is called by Validate after the
+// rules of the struct tags were checked
+type ValidatorInterface interface {
+	Validate() error
+}
+
+// ValidationError describes a value which violates a rule, the path contains the JSON path of the value i.e.
+// arrayObject[1].name
+type ValidationError struct {
+	Path    string
+	Rule    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors contains all rule violations of a value
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	var messages = make([]string, 0, len(e))
+	for _, validationError := range e {
+		messages = append(messages, validationError.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the value against the rules of the validate struct tags and calls the Validate hook of all values
+// which implement the ValidatorInterface. A tag contains a comma separated list of rules i.e.
+// `validate:"required,minLength=3,maxLength=64,format=email"`, the following rules are available:
+// required, min, max, minLength, maxLength, minItems, maxItems, enum (values separated by |), format (email, uuid,
+// uri, date, time, date-time) and pattern. Since a pattern may contain a comma it must be the last rule of a tag, the
+// rules pattern, enum and format are not applied to an empty value unless the field is also required
+func Validate(value interface{}) error {
+	var validator = &validator{}
+
+	err := validator.validateValue("", addressableValue(reflect.ValueOf(value)), nil)
+	if err != nil {
+		return err
+	}
+
+	if len(validator.errors) > 0 {
+		return validator.errors
+	}
+
+	return nil
+}
+
+type validationRule struct {
+	name    string
+	value   string
+	number  float64
+	pattern *regexp.Regexp
+}
+
+type validationField struct {
+	index []int
+	name  string
+	rules []validationRule
+}
+
+var validationFields sync.Map
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// optionalValue is implemented by the Nullable type so that the rules are applied to the contained value
+type optionalValue interface {
+	getOptionalValue() (value interface{}, set bool, null bool)
+}
+
+type validator struct {
+	errors ValidationErrors
+}
+
+func (validator *validator) validateValue(path string, value reflect.Value, rules []validationRule) error {
+	if !value.IsValid() {
+		validator.checkRequired(path, rules, false)
+		return nil
+	} else if !value.CanInterface() {
+		return nil
+	} else if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		validator.checkRequired(path, rules, false)
+		return nil
+	}
+
+	if optional, ok := value.Interface().(optionalValue); ok {
+		inner, set, null := optional.getOptionalValue()
+		validator.checkRequired(path, rules, set)
+		if !set || null {
+			return nil
+		}
+
+		return validator.validateValue(path, addressableValue(reflect.ValueOf(inner)), withoutRequired(rules))
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return validator.validateValue(path, value.Elem(), rules)
+	case reflect.Slice, reflect.Map:
+		if value.IsNil() {
+			validator.checkRequired(path, rules, false)
+			return nil
+		}
+	}
+
+	err := validator.checkRules(path, value, rules)
+	if err != nil {
+		return err
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if isScalarStruct(value.Type()) {
+			break
+		}
+
+		fields, err := getValidationFields(value.Type())
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			err = validator.validateValue(joinValidationPath(path, field.name), value.FieldByIndex(field.index), field.rules)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			err = validator.validateValue(path+"["+strconv.Itoa(i)+"]", value.Index(i), nil)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		var iterator = value.MapRange()
+		for iterator.Next() {
+			key, _ := EncodeValue(iterator.Key().Interface())
+			err = validator.validateValue(path+"["+key+"]", iterator.Value(), nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	validator.callHook(path, value)
+
+	return nil
+}
+
+func (validator *validator) checkRequired(path string, rules []validationRule, present bool) {
+	if present {
+		return
+	}
+
+	for _, rule := range rules {
+		if rule.name == "required" {
+			validator.addError(path, rule.name, "the value is required")
+		}
+	}
+}
+
+func (validator *validator) checkRules(path string, value reflect.Value, rules []validationRule) error {
+	var required = hasRule(rules, "required")
+	for _, rule := range rules {
+		switch rule.name {
+		case "required":
+			if value.Kind() == reflect.String && value.Len() == 0 {
+				validator.addError(path, rule.name, "the value is required")
+			}
+		case "min", "max":
+			number, ok := getValidationNumber(value)
+			if !ok {
+				return errors.New("the rule " + rule.name + " can only be used for numbers: " + path)
+			}
+
+			if rule.name == "min" && number < rule.number {
+				validator.addError(path, rule.name, "must be greater than or equal to "+rule.value)
+			} else if rule.name == "max" && number > rule.number {
+				validator.addError(path, rule.name, "must be less than or equal to "+rule.value)
+			}
+		case "minLength", "maxLength":
+			if value.Kind() != reflect.String {
+				return errors.New("the rule " + rule.name + " can only be used for strings: " + path)
+			}
+
+			var length = float64(utf8.RuneCountInString(value.String()))
+			if rule.name == "minLength" && length < rule.number {
+				validator.addError(path, rule.name, "must contain at least "+rule.value+" characters")
+			} else if rule.name == "maxLength" && length > rule.number {
+				validator.addError(path, rule.name, "must contain at most "+rule.value+" characters")
+			}
+		case "minItems", "maxItems":
+			if value.Kind() != reflect.Slice && value.Kind() != reflect.Array && value.Kind() != reflect.Map {
+				return errors.New("the rule " + rule.name + " can only be used for arrays and maps: " + path)
+			}
+
+			var length = float64(value.Len())
+			if rule.name == "minItems" && length < rule.number {
+				validator.addError(path, rule.name, "must contain at least "+rule.value+" items")
+			} else if rule.name == "maxItems" && length > rule.number {
+				validator.addError(path, rule.name, "must contain at most "+rule.value+" items")
+			}
+		case "pattern", "enum", "format":
+			text, err := EncodeValue(value.Interface())
+			if err != nil {
+				return err
+			}
+
+			if text == "" && !required {
+				// an empty value of an optional field i.e. the zero value of a string is not checked
+				continue
+			}
+
+			if rule.name == "pattern" && !rule.pattern.MatchString(text) {
+				validator.addError(path, rule.name, "must match the pattern "+rule.value)
+			} else if rule.name == "enum" && !containsString(strings.Split(rule.value, "|"), text) {
+				validator.addError(path, rule.name, "must be one of "+strings.ReplaceAll(rule.value, "|", ", "))
+			} else if rule.name == "format" && !isValidFormat(rule.value, text) {
+				validator.addError(path, rule.name, "must be a valid "+rule.value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// callHook calls the Validate method of the value, errors of the hook are added relative to the path of the value
+func (validator *validator) callHook(path string, value reflect.Value) {
+	hook, _ := value.Interface().(ValidatorInterface)
+	if hook == nil && value.CanAddr() {
+		hook, _ = value.Addr().Interface().(ValidatorInterface)
+	}
+
+	if hook == nil {
+		return
+	}
+
+	err := hook.Validate()
+	if err == nil {
+		return
+	}
+
+	var validationErrors ValidationErrors
+	var validationError *ValidationError
+	if errors.As(err, &validationErrors) {
+		for _, item := range validationErrors {
+			validator.addError(joinValidationPath(path, item.Path), item.Rule, item.Message)
+		}
+	} else if errors.As(err, &validationError) {
+		validator.addError(joinValidationPath(path, validationError.Path), validationError.Rule, validationError.Message)
+	} else {
+		validator.addError(path, "validate", err.Error())
+	}
+}
+
+func (validator *validator) addError(path string, rule string, message string) {
+	validator.errors = append(validator.errors, ValidationError{Path: path, Rule: rule, Message: message})
+}
+
+func getValidationFields(structType reflect.Type) ([]validationField, error) {
+	if fields, ok := validationFields.Load(structType); ok {
+		return fields.([]validationField), nil
+	}
+
+	var fields []validationField
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || (field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "") {
+			continue
+		}
+
+		var name = field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			} else if tagName != "" {
+				name = tagName
+			}
+		}
+
+		rules, err := parseValidationRules(field.Tag.Get("validate"))
+		if err != nil {
+			return nil, errors.New("the field " + structType.String() + "." + field.Name + " contains an invalid rule: " + err.Error())
+		}
+
+		fields = append(fields, validationField{index: field.Index, name: name, rules: rules})
+	}
+
+	validationFields.Store(structType, fields)
+
+	return fields, nil
+}
+
+func parseValidationRules(tag string) ([]validationRule, error) {
+	var rules []validationRule
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "pattern=") {
+			part, tag = tag, ""
+		} else {
+			part, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, value, _ := strings.Cut(part, "=")
+		var rule = validationRule{name: name, value: value}
+
+		switch name {
+		case "required":
+		case "min", "max", "minLength", "maxLength", "minItems", "maxItems":
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, errors.New("the rule " + name + " requires a number")
+			}
+
+			rule.number = number
+		case "pattern":
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return nil, err
+			}
+
+			rule.pattern = pattern
+		case "enum":
+		case "format":
+			if !containsString([]string{"email", "uuid", "uri", "date", "time", "date-time"}, value) {
+				return nil, errors.New("the format " + value + " is not supported")
+			}
+		default:
+			return nil, errors.New("the rule " + name + " is not supported")
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func isValidFormat(format string, value string) bool {
+	switch format {
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "uri":
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.IsAbs()
+	case "date":
+		_, err := ParseDate(value)
+		return err == nil
+	case "time":
+		_, err := ParseTime(value)
+		return err == nil
+	case "date-time":
+		_, err := ParseDateTime(value)
+		return err == nil
+	}
+
+	return false
+}
+
+func getValidationNumber(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.String:
+		number, err := strconv.ParseFloat(value.String(), 64)
+		return number, err == nil && value.Type() == reflect.TypeOf(json.Number(""))
+	}
+
+	return 0, false
+}
+
+// addressableValue copies the value into a new variable so that the value and all fields are addressable, otherwise
+// a Validate hook with a pointer receiver could not be called for a struct which was passed by value
+func addressableValue(value reflect.Value) reflect.Value {
+	if !value.IsValid() || value.CanAddr() {
+		return value
+	}
+
+	var result = reflect.New(value.Type()).Elem()
+	result.Set(value)
+
+	return result
+}
+
+// isScalarStruct returns whether the struct represents a scalar value whose fields must not be validated
+func isScalarStruct(structType reflect.Type) bool {
+	switch structType {
+	case reflect.TypeOf(Date{}), reflect.TypeOf(Time{}), reflect.TypeOf(DateTime{}):
+		return true
+	}
+
+	return false
+}
+
+func hasRule(rules []validationRule, name string) bool {
+	for _, rule := range rules {
+		if rule.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withoutRequired(rules []validationRule) []validationRule {
+	var result = make([]validationRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.name != "required" {
+			result = append(result, rule)
+		}
+	}
+
+	return result
+}
+
+func joinValidationPath(path string, name string) string {
+	if path == "" {
+		return name
+	} else if name == "" {
+		return path
+	} else if strings.HasPrefix(name, "[") {
+		return path + name
+	}
+
+	return path + "." + name
+}
+
+func containsString(values []string, needle string) bool {
+	for _, value := range values {
+		if value == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,159 @@
+package sdkgen
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnsupportedValueError is returned in case a value can not be encoded as path, query or header parameter
+type UnsupportedValueError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedValueError) Error() string {
+	return "the value of type " + e.Type.String() + " can not be encoded as parameter"
+}
+
+// EncodeValue returns the string representation of a parameter value. Values which implement encoding.TextMarshaler or
+// fmt.Stringer are encoded through these methods, named types are encoded according to their kind and slices are
+// encoded as comma separated list. A date or time must be wrapped through the Date, Time or DateTime type, a plain
+// time.Time is encoded as RFC 3339 date-time
+func EncodeValue(value interface{}) (string, error) {
+	if isNilValue(value) {
+		return "", nil
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return encodeBool(value), nil
+	case int:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	case json.Number:
+		return value.String(), nil
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return "", err
+		}
+
+		return string(text), nil
+	case fmt.Stringer:
+		return value.String(), nil
+	case json.Marshaler:
+		return encodeJsonValue(value)
+	}
+
+	return encodeReflectValue(reflect.ValueOf(value))
+}
+
+func encodeReflectValue(value reflect.Value) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return encodeBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return "", nil
+		}
+
+		return EncodeValue(value.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		var items = make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := EncodeValue(value.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, item)
+		}
+
+		return strings.Join(items, ","), nil
+	}
+
+	return "", &UnsupportedValueError{Type: value.Type()}
+}
+
+// encodeJsonValue uses the JSON representation of the value in case it is a scalar JSON value
+func encodeJsonValue(value json.Marshaler) (string, error) {
+	raw, err := value.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	var result interface{}
+	var decoder = json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&result)
+	if err != nil {
+		return "", err
+	}
+
+	switch result := result.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return result, nil
+	case bool:
+		return encodeBool(result), nil
+	case json.Number:
+		return result.String(), nil
+	}
+
+	return "", &UnsupportedValueError{Type: reflect.TypeOf(value)}
+}
+
+// isScalarValue returns whether the value is encoded as a single value, this is the case for all values which are
+// encoded through a text marshaler or stringer even if the underlying type is i.e. a slice
+func isScalarValue(value interface{}) bool {
+	if isNilValue(value) {
+		return false
+	}
+
+	switch value.(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+		return true
+	}
+
+	return false
+}
+
+// isNilValue returns whether the value is nil or a nil pointer, a method of a nil pointer i.e. *time.Time can not be
+// called since the method has a value receiver
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	var reflected = reflect.ValueOf(value)
+
+	return reflected.Kind() == reflect.Ptr && reflected.IsNil()
+}
+
+func encodeBool(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
@@ -0,0 +1,532 @@
+package sdkgen
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContinuationMessage = 0
+	TextMessage         = 1
+	BinaryMessage       = 2
+	CloseMessage        = 8
+	PingMessage         = 9
+	PongMessage         = 10
+)
+
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseUnsupportedData  = 1003
+	CloseNoStatusReceived = 1005
+	CloseInvalidPayload   = 1007
+	CloseMessageTooBig    = 1009
+)
+
+const webSocketGuid = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocketCloseError is returned by ReadMessage in case the connection was closed by the server
+type WebSocketCloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *WebSocketCloseError) Error() string {
+	return "websocket closed with code " + strconv.Itoa(e.Code) + ": " + e.Reason
+}
+
+// WebSocketDialer opens a WebSocket connection, the handshake request is passed to the authenticator so that the same
+// credentials as for the REST operations are used
+type WebSocketDialer struct {
+	Authenticator  AuthenticatorInterface
+	Header         http.Header
+	Subprotocols   []string
+	TLSConfig      *tls.Config
+	Version        string
+	MaxMessageSize int64
+}
+
+func (dialer *WebSocketDialer) Dial(ctx context.Context, rawUrl string) (*WebSocket, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, errors.New("could not parse websocket url")
+	}
+
+	var secure bool
+	switch u.Scheme {
+	case "ws", "http":
+		u.Scheme = "http"
+	case "wss", "https":
+		u.Scheme = "https"
+		secure = true
+	default:
+		return nil, errors.New("unsupported websocket scheme: " + u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range dialer.Header {
+		req.Header[name] = values
+	}
+
+	var key = base64.StdEncoding.EncodeToString(randomBytes(16))
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if len(dialer.Subprotocols) > 0 {
+		req.Header.Set("Sec-WebSocket-Protocol", strings.Join(dialer.Subprotocols, ", "))
+	}
+
+	if dialer.Version != "" {
+		req.Header.Set("User-Agent", "SDKgen/"+dialer.Version)
+	} else {
+		req.Header.Set("User-Agent", "SDKgen")
+	}
+
+	if dialer.Authenticator != nil {
+		req, err = dialer.Authenticator.Intercept(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var address = u.Host
+	if u.Port() == "" {
+		if secure {
+			address = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			address = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var netDialer net.Dialer
+	conn, err := netDialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if secure {
+		var config = &tls.Config{}
+		if dialer.TLSConfig != nil {
+			config = dialer.TLSConfig.Clone()
+		}
+
+		if config.ServerName == "" {
+			config.ServerName = u.Hostname()
+		}
+
+		var tlsConn = tls.Client(conn, config)
+		err = tlsConn.HandshakeContext(ctx)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	err = req.Write(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	var reader = bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body.Close()
+		conn.Close()
+		return nil, errors.New("the websocket handshake failed with status code: " + strconv.Itoa(resp.StatusCode))
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") || !headerContainsToken(resp.Header, "Connection", "upgrade") {
+		conn.Close()
+		return nil, errors.New("the server did not upgrade the connection to websocket")
+	}
+
+	if resp.Header.Get("Sec-WebSocket-Accept") != computeAcceptKey(key) {
+		conn.Close()
+		return nil, errors.New("the server returned an invalid websocket accept key")
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	return &WebSocket{
+		conn:           conn,
+		reader:         reader,
+		subprotocol:    resp.Header.Get("Sec-WebSocket-Protocol"),
+		maxMessageSize: dialer.MaxMessageSize,
+		closeReceived:  make(chan struct{}),
+	}, nil
+}
+
+func NewWebSocketDialer(authenticator AuthenticatorInterface) *WebSocketDialer {
+	return &WebSocketDialer{
+		Authenticator: authenticator,
+	}
+}
+
+// WebSocket is a client connection according to RFC 6455. Pings are answered automatically, a single goroutine may
+// read messages while other goroutines write messages
+type WebSocket struct {
+	PongHandler      func(data []byte)
+	conn             net.Conn
+	reader           *bufio.Reader
+	subprotocol      string
+	maxMessageSize   int64
+	readMutex        sync.Mutex
+	writeMutex       sync.Mutex
+	closeSent        bool
+	closeReceived    chan struct{}
+	closeReceiveOnce sync.Once
+}
+
+func (ws *WebSocket) GetSubprotocol() string {
+	return ws.subprotocol
+}
+
+func (ws *WebSocket) ReadMessage() (int, []byte, error) {
+	ws.readMutex.Lock()
+	defer ws.readMutex.Unlock()
+
+	return ws.readMessage()
+}
+
+func (ws *WebSocket) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return errors.New("only text and binary messages can be written")
+	}
+
+	if messageType == TextMessage && !utf8.Valid(data) {
+		return errors.New("a text message must contain valid UTF-8")
+	}
+
+	return ws.writeFrame(messageType, data)
+}
+
+func (ws *WebSocket) ReadJson(target interface{}) error {
+	_, data, err := ws.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+func (ws *WebSocket) WriteJson(value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return ws.writeFrame(TextMessage, raw)
+}
+
+func (ws *WebSocket) Ping(data []byte) error {
+	if len(data) > 125 {
+		return errors.New("the payload of a control frame must not exceed 125 bytes")
+	}
+
+	return ws.writeFrame(PingMessage, data)
+}
+
+func (ws *WebSocket) Close() error {
+	return ws.CloseWithReason(CloseNormalClosure, "")
+}
+
+// CloseWithReason starts the closing handshake and waits until the server has confirmed the close or the timeout
+// is reached, afterwards the underlying connection is closed
+func (ws *WebSocket) CloseWithReason(code int, reason string) error {
+	err := ws.sendClose(code, reason)
+	if err != nil {
+		ws.conn.Close()
+		return err
+	}
+
+	select {
+	case <-ws.closeReceived:
+		return ws.conn.Close()
+	default:
+	}
+
+	var deadline = time.Now().Add(5 * time.Second)
+	if ws.readMutex.TryLock() {
+		ws.conn.SetReadDeadline(deadline)
+		for {
+			_, _, err := ws.readMessage()
+			if err != nil {
+				break
+			}
+		}
+
+		ws.readMutex.Unlock()
+	} else {
+		select {
+		case <-ws.closeReceived:
+		case <-time.After(time.Until(deadline)):
+		}
+	}
+
+	return ws.conn.Close()
+}
+
+func (ws *WebSocket) readMessage() (int, []byte, error) {
+	var messageType = 0
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			err = ws.writeFrame(PongMessage, payload)
+			if err != nil {
+				return 0, nil, err
+			}
+		case PongMessage:
+			if ws.PongHandler != nil {
+				ws.PongHandler(payload)
+			}
+		case CloseMessage:
+			return 0, nil, ws.handleClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, ws.failConnection(CloseProtocolError, "received a new message before the fragmented message was completed")
+			}
+
+			messageType = opcode
+			message = payload
+		case ContinuationMessage:
+			if messageType == 0 {
+				return 0, nil, ws.failConnection(CloseProtocolError, "received a continuation frame without a message")
+			}
+
+			if ws.maxMessageSize > 0 && int64(len(message)+len(payload)) > ws.maxMessageSize {
+				return 0, nil, ws.failConnection(CloseMessageTooBig, "the message exceeds the maximum size")
+			}
+
+			message = append(message, payload...)
+		default:
+			return 0, nil, ws.failConnection(CloseProtocolError, "received an unknown opcode")
+		}
+
+		if messageType != 0 && fin && opcode < CloseMessage {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, ws.failConnection(CloseInvalidPayload, "received a text message with invalid UTF-8")
+			}
+
+			return messageType, message, nil
+		}
+	}
+}
+
+func (ws *WebSocket) readFrame() (bool, int, []byte, error) {
+	var header [2]byte
+	_, err := io.ReadFull(ws.reader, header[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	var fin = header[0]&0x80 != 0
+	var opcode = int(header[0] & 0x0f)
+	var masked = header[1]&0x80 != 0
+	var length = uint64(header[1] & 0x7f)
+
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, ws.failConnection(CloseProtocolError, "received a frame with reserved bits")
+	}
+
+	if masked {
+		return false, 0, nil, ws.failConnection(CloseProtocolError, "received a masked frame from the server")
+	}
+
+	if length == 126 {
+		var extended [2]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	} else if length == 127 {
+		var extended [8]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+		if length>>63 != 0 {
+			return false, 0, nil, ws.failConnection(CloseProtocolError, "received an invalid payload length")
+		}
+	}
+
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	if opcode >= CloseMessage && (!fin || length > 125) {
+		return false, 0, nil, ws.failConnection(CloseProtocolError, "received an invalid control frame")
+	}
+
+	if ws.maxMessageSize > 0 && length > uint64(ws.maxMessageSize) {
+		return false, 0, nil, ws.failConnection(CloseMessageTooBig, "the message exceeds the maximum size")
+	}
+
+	var payload = make([]byte, length)
+	_, err = io.ReadFull(ws.reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (ws *WebSocket) writeFrame(opcode int, payload []byte) error {
+	ws.writeMutex.Lock()
+	defer ws.writeMutex.Unlock()
+
+	if ws.closeSent {
+		return errors.New("the websocket connection is closed")
+	}
+
+	var frame = make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+
+	var length = len(payload)
+	if length <= 125 {
+		frame = append(frame, 0x80|byte(length))
+	} else if length <= 0xffff {
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[len(frame)-2:], uint16(length))
+	} else {
+		frame = append(frame, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[len(frame)-8:], uint64(length))
+	}
+
+	// every frame which is sent by a client must be masked
+	var mask = randomBytes(4)
+	frame = append(frame, mask...)
+	for index, value := range payload {
+		frame = append(frame, value^mask[index%4])
+	}
+
+	_, err := ws.conn.Write(frame)
+	if err != nil {
+		return err
+	}
+
+	if opcode == CloseMessage {
+		ws.closeSent = true
+	}
+
+	return nil
+}
+
+func (ws *WebSocket) sendClose(code int, reason string) error {
+	ws.writeMutex.Lock()
+	var closeSent = ws.closeSent
+	ws.writeMutex.Unlock()
+
+	if closeSent {
+		return nil
+	}
+
+	var payload []byte
+	if code != CloseNoStatusReceived {
+		payload = make([]byte, 2, 2+len(reason))
+		binary.BigEndian.PutUint16(payload, uint16(code))
+		payload = append(payload, reason...)
+		if len(payload) > 125 {
+			payload = payload[:125]
+		}
+	}
+
+	return ws.writeFrame(CloseMessage, payload)
+}
+
+func (ws *WebSocket) handleClose(payload []byte) error {
+	ws.closeReceiveOnce.Do(func() {
+		close(ws.closeReceived)
+	})
+
+	var closeError = &WebSocketCloseError{Code: CloseNoStatusReceived}
+	if len(payload) == 1 {
+		return ws.failConnection(CloseProtocolError, "received an invalid close frame")
+	} else if len(payload) >= 2 {
+		closeError.Code = int(binary.BigEndian.Uint16(payload))
+		closeError.Reason = string(payload[2:])
+		if !utf8.Valid(payload[2:]) {
+			return ws.failConnection(CloseProtocolError, "received an invalid close reason")
+		}
+	}
+
+	// we confirm the close of the server with the same code, in case we have started the handshake this is a no-op
+	_ = ws.sendClose(closeError.Code, "")
+
+	return closeError
+}
+
+func (ws *WebSocket) failConnection(code int, reason string) error {
+	_ = ws.sendClose(code, reason)
+	ws.conn.Close()
+
+	return &WebSocketCloseError{Code: code, Reason: reason}
+}
+
+func ReadJsonMessage[T any](ws *WebSocket) (T, error) {
+	var data T
+	err := ws.ReadJson(&data)
+
+	return data, err
+}
+
+func computeAcceptKey(key string) string {
+	var hash = sha1.Sum([]byte(key + webSocketGuid))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func headerContainsToken(header http.Header, name string, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func randomBytes(size int) []byte {
+	var data = make([]byte, size)
+	_, _ = rand.Read(data)
+
+	return data
+}
@@ -0,0 +1,79 @@
+package sdkgen
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// XmlCodec encodes and decodes models through encoding/xml, namespaces and attributes are defined through the xml
+// struct tags i.e. `xml:"urn:example product"` or `xml:"id,attr"`. If Header is true the XML declaration is written
+// before the document
+type XmlCodec struct {
+	Header bool
+}
+
+func (codec *XmlCodec) ContentType() string {
+	return "application/xml"
+}
+
+func (codec *XmlCodec) Encode(value interface{}) ([]byte, error) {
+	raw, err := xml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if codec.Header {
+		return append([]byte(xml.Header), raw...), nil
+	}
+
+	return raw, nil
+}
+
+func (codec *XmlCodec) Decode(body io.Reader, target interface{}) error {
+	var decoder = xml.NewDecoder(body)
+	decoder.CharsetReader = newXmlCharsetReader
+
+	return decoder.Decode(target)
+}
+
+func NewXmlCodec() *XmlCodec {
+	return &XmlCodec{}
+}
+
+// newXmlCharsetReader supports the ISO-8859-1 charset which is often used by legacy services, encoding/xml only
+// handles UTF-8 by itself
+func newXmlCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		return &latin1Reader{reader: input}, nil
+	}
+
+	return nil, errors.New("the charset " + charset + " is not supported")
+}
+
+type latin1Reader struct {
+	reader  io.Reader
+	pending []byte
+}
+
+func (reader *latin1Reader) Read(p []byte) (int, error) {
+	if len(reader.pending) == 0 {
+		var buffer = make([]byte, (len(p)+1)/2)
+		n, err := reader.reader.Read(buffer)
+		for _, char := range buffer[:n] {
+			reader.pending = utf8.AppendRune(reader.pending, rune(char))
+		}
+
+		if n == 0 {
+			return 0, err
+		}
+	}
+
+	var n = copy(p, reader.pending)
+	reader.pending = reader.pending[n:]
+
+	return n, nil
+}